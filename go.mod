@@ -2,11 +2,18 @@ module github.com/ron96g/json-schema-gen
 
 go 1.25.5
 
+require (
+	github.com/emicklei/proto v1.14.3
+	github.com/invopop/jsonschema v0.13.0
+	github.com/wk8/go-ordered-map/v2 v2.1.8
+	golang.org/x/tools v0.46.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
 require (
 	github.com/bahlo/generic-list-go v0.2.0 // indirect
 	github.com/buger/jsonparser v1.1.1 // indirect
-	github.com/invopop/jsonschema v0.13.0 // indirect
 	github.com/mailru/easyjson v0.7.7 // indirect
-	github.com/wk8/go-ordered-map/v2 v2.1.8 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
+	golang.org/x/mod v0.37.0 // indirect
+	golang.org/x/sync v0.21.0 // indirect
 )
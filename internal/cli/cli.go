@@ -4,16 +4,82 @@ package cli
 import (
 	"flag"
 	"fmt"
+	"io"
+	"net/url"
 	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/ron96g/json-schema-gen/internal/parser"
 )
 
 // Config holds CLI configuration.
 type Config struct {
-	OutputDir string   // Output directory for schema files
-	NameTag   string   // Tag for property names (json, yaml, etc.)
-	SchemaID  string   // Base URL for $id field
-	Paths     []string // Input paths (files or directories)
-	Recursive bool     // Recursively scan directories for packages
+	OutputDir          string                        // Output directory for schema files
+	NameTag            string                        // Tag for property names (json, yaml, etc.)
+	SchemaID           string                        // Base URL for $id field
+	SchemaURI          string                        // Root $schema value, overriding the draft default
+	Paths              []string                      // Input paths (files or directories)
+	Recursive          bool                          // Recursively scan directories for packages
+	CrossModule        bool                          // Descend into nested modules when scanning recursively
+	PropertyCase       string                        // Case of property names for untagged fields
+	StripPrefix        string                        // Prefix removed from all property names
+	QualifyRefs        bool                          // Prefix schema files and refs with the package (pkg_config.schema.json)
+	CommentDirectives  []string                      // Extra comment prefixes dropped from descriptions
+	PreserveNewlines   bool                          // Keep line breaks and paragraphs of comments in descriptions
+	IncludeTests       bool                          // Also parse _test.go files in directories
+	IncludeTestdata    bool                          // Descend into testdata directories when scanning recursively
+	IncludeVendor      bool                          // Descend into vendor directories when scanning recursively
+	PackageMode        bool                          // Treat paths as Go package patterns (./..., example.com/models)
+	OpenAPIVersion     string                        // OpenAPI version for nullable pointers (3.0 or 3.1)
+	NullablePointers   bool                          // Add "null" to the type of pointer fields
+	FlattenSingleField bool                          // Replace refs to single-field structs with the field's schema
+	HumanizeTitles     bool                          // Humanize struct names for the title field
+	OutputRelativeTo   string                        // Base for a relative output dir (cwd or file)
+	FailOnWarning      bool                          // Exit with an error if any warnings were reported
+	TagPriority        []string                      // Validation tags to merge, in priority order
+	IntrinsicBounds    bool                          // Emit minimum/maximum for sized integer types
+	RuneAsString       bool                          // Emit standalone rune fields as single-character strings
+	HoistThreshold     int                           // Hoist inline types referenced at least N times into $defs
+	BuildTags          []string                      // Build tags for evaluating //go:build constraints
+	MarshalerAs        string                        // Schema type for json.Marshaler types
+	TypeMappings       map[string]parser.TypeMapping // External types mapped to primitives
+	Extension          string                        // Schema file extension
+	Formats            []string                      // Output formats (json, yaml)
+	BaseRef            string                        // Base schema every root schema extends via allOf
+	DocumentedEnums    bool                          // Emit commented enum values as oneOf const+description
+	EnumVarnames       bool                          // Emit constant names of enums as x-enum-varnames
+	EnumNames          bool                          // Emit labels derived from constant names as x-enumNames
+	RefStyle           string                        // How $refs point to other schemas (file, defs or id)
+	KeepGoing          bool                          // Continue past per-type errors and report them together
+	MaxErrors          int                           // Maximum number of errors listed with --keep-going (0 for no limit)
+	Only               []string                      // Only generate these types (and their dependencies)
+	Skip               []string                      // Annotated types to exclude from generation
+	Stamp              bool                          // Record the generator name and version as x-generator
+	NoRequired         bool                          // Suppress required arrays
+	Incremental        bool                          // Skip schemas that are newer than their sources
+	EmbedMode          string                        // Embedded structs: flatten fields or compose via allOf (flatten/ref)
+	Since              string                        // Git ref; only regenerate types whose sources changed since
+	RequiredNonEmpty   bool                          // Required slices and maps get minItems/minProperties 1
+	TitleFromComment   bool                          // Use the first doc comment sentence as title
+	ExamplesFromEnum   bool                          // Emit the first enum value of fields as example
+	ReadOnlyFields     *regexp.Regexp                // Fields marked readOnly, matched by property or Go name
+	WarnUnmappedTypes  bool                          // Warn about external types emitted as a bare object
+	AutoFieldTitles    bool                          // Title fields with their humanized property name
+	InputFormat        string                        // Format of the input files (go or proto)
+	EmitTSEnums        bool                          // Write a .ts union type for every extracted enum
+	DeterministicMaps  bool                          // Constrain enum and integer map keys via propertyNames
+	ExcludeFields      *regexp.Regexp                // Fields dropped from all schemas, matched by property or Go name
+	RegistryFile       bool                          // Write index.schema.json with $refs to every generated schema
+	AddSchemaComment   bool                          // Record the source file as $comment of root schemas
+	GroupOutputBy      string                        // Write one schema per type or per source file (type/file)
+
+	RequiredUnlessOmitEmpty bool // Require all fields except pointers and omitempty fields
+	RequiredStringsNonEmpty bool // Required strings get minLength 1
+
+	HelpValidators bool // Print supported validators and exit
 }
 
 // Parse parses command-line arguments and returns configuration.
@@ -21,10 +87,67 @@ func Parse() (*Config, error) {
 	cfg := &Config{}
 
 	flag.StringVar(&cfg.OutputDir, "output-dir", "", "Output directory for schema files (required)")
-	flag.StringVar(&cfg.NameTag, "tag", "json", "Tag for property names (json/yaml/mapstructure)")
+	flag.StringVar(&cfg.NameTag, "tag", "json", "Tag for property names (json/yaml/mapstructure/xml/form/query)")
+	flag.StringVar(&cfg.PropertyCase, "property-case", "original", "Case of property names for fields without a name tag (camel/snake/pascal/original)")
+	flag.StringVar(&cfg.StripPrefix, "strip-prefix", "", "Prefix removed from all property names, tagged or not (e.g., x_)")
+	flag.BoolVar(&cfg.QualifyRefs, "qualify-refs", false, "Prefix schema file names and refs with the package name (pkg_config.schema.json) so same-named types of different packages do not collide")
+	commentDirectives := flag.String("comment-directives", "", "Comma-separated comment prefixes dropped from descriptions, in addition to go:, nolint, lint:, revive:, #nosec, ...")
+	flag.BoolVar(&cfg.PreserveNewlines, "preserve-newlines", false, "Keep line breaks and blank-line paragraphs of doc comments in descriptions (Markdown)")
 	flag.StringVar(&cfg.SchemaID, "schema-id", "", "Base URL for $id field")
+	flag.StringVar(&cfg.SchemaURI, "schema-uri", "", "Root $schema value, e.g. an internally hosted meta-schema (default: the JSON Schema 2020-12 URI)")
+	flag.StringVar(&cfg.RefStyle, "ref-style", "file", "How $refs point to other schemas: file (user.schema.json), defs (bundled as #/$defs/User) or id (absolute URL under --schema-id)")
+	flag.StringVar(&cfg.EmbedMode, "embed-mode", "flatten", "Embedded structs: promote their fields into the parent, or extend the parent via allOf with a $ref (flatten/ref)")
+	flag.StringVar(&cfg.BaseRef, "base-ref", "", "Wrap each root schema as allOf [{$ref: URL}, {...}] to extend a shared base schema")
 	flag.BoolVar(&cfg.Recursive, "recursive", false, "Recursively scan directories (requires // +schema annotation)")
 	flag.BoolVar(&cfg.Recursive, "r", false, "Recursively scan directories (shorthand for --recursive)")
+	flag.BoolVar(&cfg.CrossModule, "cross-module", false, "With --recursive, also scan nested modules (directories with their own go.mod)")
+	flag.BoolVar(&cfg.IncludeTests, "include-tests", false, "Also parse _test.go files when scanning directories")
+	flag.BoolVar(&cfg.IncludeTestdata, "include-testdata", false, "With --recursive, also scan testdata directories")
+	flag.BoolVar(&cfg.IncludeVendor, "include-vendor", false, "With --recursive, also scan vendor directories")
+	flag.BoolVar(&cfg.PackageMode, "package", false, "Treat paths as Go package patterns (./..., example.com/models) loaded via the module graph")
+	flag.StringVar(&cfg.OpenAPIVersion, "openapi-version", "", "Emit nullable pointer fields for OpenAPI (3.0/3.1)")
+	flag.BoolVar(&cfg.NullablePointers, "nullable-pointers", false, "Add \"null\" to the type of pointer fields ([\"string\", \"null\"])")
+	flag.BoolVar(&cfg.FlattenSingleField, "flatten-single-field", false, "Replace references to single-field wrapper structs with the schema of their field")
+	flag.BoolVar(&cfg.HumanizeTitles, "humanize-titles", false, "Use human-readable titles (ServiceConfig -> Service Config)")
+	flag.BoolVar(&cfg.TitleFromComment, "title-from-comment", false, "Use the first sentence of a struct's doc comment as title and the rest as description")
+	flag.StringVar(&cfg.OutputRelativeTo, "output-relative-to", "cwd", "Base for a relative --output-dir: working directory or source file directory (cwd/file)")
+	tagPriority := flag.String("tag-priority", "validate", "Comma-separated validation tags to merge, highest priority first (validate/binding)")
+	flag.BoolVar(&cfg.IntrinsicBounds, "intrinsic-bounds", false, "Emit minimum/maximum from sized integer types (int8, uint16, ...)")
+	flag.BoolVar(&cfg.RuneAsString, "rune-as-string", false, "Emit standalone rune fields as single-character strings")
+	flag.IntVar(&cfg.HoistThreshold, "hoist-threshold", 0, "In +schema:inline structs, move types used at least N times into $defs (0 disables)")
+	buildTags := flag.String("build-tags", "", "Comma-separated build tags; files with unsatisfied //go:build constraints are skipped")
+	flag.StringVar(&cfg.MarshalerAs, "marshaler-as", "any", "Schema for types implementing json.Marshaler (any/string/object/number/integer/boolean/array)")
+	flag.StringVar(&cfg.Extension, "extension", ".schema.json", "File extension for generated schemas, also used in $ref paths (e.g., .json)")
+	formats := flag.String("format", "json", "Comma-separated output formats written for every schema (json/yaml)")
+	flag.BoolVar(&cfg.WarnUnmappedTypes, "warn-unmapped-types", false, "Warn about fields whose external types are emitted as a bare object (add them with --type-map)")
+	typeMap := flag.String("type-map", "", "Comma-separated external type mappings pkg.Type=target[:nullable] (e.g., null.String=string:nullable)")
+	flag.BoolVar(&cfg.DocumentedEnums, "documented-enums", false, "Emit enum constants with comments as oneOf const+description entries instead of a plain enum")
+	flag.BoolVar(&cfg.AutoFieldTitles, "auto-field-titles", false, "Set each field's title to its humanized property name (zip_code -> Zip Code) for form generators")
+	flag.BoolVar(&cfg.DeterministicMaps, "deterministic-maps", false, "Emit propertyNames for maps keyed by enum types (their values) or integers (decimal digits)")
+	flag.BoolVar(&cfg.AddSchemaComment, "add-schema-comment", false, "Add a $comment to each root schema naming the source file it was generated from")
+	flag.StringVar(&cfg.GroupOutputBy, "group-output-by", "type", "Write one schema per type, or one per source file bundling its annotated structs under $defs (type/file)")
+	flag.BoolVar(&cfg.RegistryFile, "registry-file", false, "Also write index.schema.json, an object whose properties are $refs to every generated schema")
+	flag.BoolVar(&cfg.EmitTSEnums, "emit-ts-enums", false, "Also write a TypeScript file with a string-literal union type (<name>.ts) for every extracted enum")
+	flag.StringVar(&cfg.InputFormat, "input-format", "go", "Format of the input files: go (annotated structs) or proto (all messages of .proto files)")
+	flag.BoolVar(&cfg.ExamplesFromEnum, "examples-from-enum", false, "Emit examples with the first allowed value of enum fields (oneof or typed constants)")
+	flag.BoolVar(&cfg.EnumNames, "enum-names-extension", false, "Emit human labels derived from enum constant names (StatusInProgress -> In Progress) as an x-enumNames extension")
+	flag.BoolVar(&cfg.EnumVarnames, "enum-varnames", false, "Emit the constant names of enums as an x-enum-varnames extension parallel to enum")
+	flag.BoolVar(&cfg.RequiredStringsNonEmpty, "required-strings-nonempty", false, "Add minLength 1 to required strings, since go-playground's required rejects the empty string")
+	flag.BoolVar(&cfg.RequiredNonEmpty, "required-nonempty", false, "Require at least one element in required slices (minItems) and maps (minProperties), like go-playground's required")
+	excludeFields := flag.String("exclude-fields", "", "Comma-separated field names or regular expressions (matching a whole property or Go field name) dropped from all schemas, e.g. Internal.*,debug")
+	readOnlyFields := flag.String("read-only-fields", "", "Comma-separated field names or regular expressions (matching a whole property or Go field name) marked readOnly, e.g. id,created_at,.*_at")
+	flag.BoolVar(&cfg.RequiredUnlessOmitEmpty, "all-required-unless-omitempty", false, "Require every field that is neither a pointer nor tagged omitempty, even without a required validator")
+	flag.BoolVar(&cfg.RequiredUnlessOmitEmpty, "infer-required", false, "Infer required fields from non-pointer, non-omitempty fields (same as --all-required-unless-omitempty)")
+	flag.BoolVar(&cfg.NoRequired, "no-required", false, "Never emit required arrays; validators still add their other constraints")
+	flag.BoolVar(&cfg.Incremental, "incremental", false, "Skip schemas whose output files are newer than the source files of the type and its dependencies")
+	flag.StringVar(&cfg.Since, "since", "", "Only regenerate schemas whose source files (or dependencies' source files) changed since a git ref, per git diff")
+	flag.BoolVar(&cfg.FailOnWarning, "fail-on-warning", false, "Exit with an error if any warnings were reported")
+	flag.BoolVar(&cfg.KeepGoing, "keep-going", false, "Continue past per-type errors and report them all at the end")
+	flag.IntVar(&cfg.MaxErrors, "max-errors", 0, "With --keep-going, list at most N errors followed by a summary (0 for no limit)")
+	only := flag.String("only", "", "Comma-separated type names to generate; referenced dependencies are still written")
+	skip := flag.String("skip", "", "Comma-separated annotated type names to exclude; still written if another schema references them")
+	flag.BoolVar(&cfg.Stamp, "stamp", false, "Add an x-generator extension with the tool name and version to each root schema")
+	flag.BoolVar(&cfg.HelpValidators, "help-validators", false, "List supported validators and the JSON Schema keywords they produce, then exit")
 
 	flag.Usage = func() {
 		fmt.Fprintf(os.Stderr, "Usage: json-schema-gen [flags] [paths...]\n\n")
@@ -34,15 +157,28 @@ func Parse() (*Config, error) {
 		fmt.Fprintf(os.Stderr, "\nExamples:\n")
 		fmt.Fprintf(os.Stderr, "  json-schema-gen --output-dir schemas ./models/\n")
 		fmt.Fprintf(os.Stderr, "  json-schema-gen --output-dir schemas --tag yaml ./api/types.go\n")
+		fmt.Fprintf(os.Stderr, "  json-schema-gen --output-dir schemas --tag form ./api/requests.go\n")
 		fmt.Fprintf(os.Stderr, "  json-schema-gen --output-dir schemas --schema-id https://example.com/schemas .\n")
 		fmt.Fprintf(os.Stderr, "  json-schema-gen --output-dir schemas --recursive .  # scan all subdirs\n")
+		fmt.Fprintf(os.Stderr, "  json-schema-gen --output-dir schemas --package ./...\n")
 		fmt.Fprintf(os.Stderr, "\nAnnotations:\n")
 		fmt.Fprintf(os.Stderr, "  // +schema         - Include struct in schema generation (uses $ref for references)\n")
 		fmt.Fprintf(os.Stderr, "  // +schema:inline  - Include struct with all references inlined (no $ref)\n")
+		fmt.Fprintf(os.Stderr, "  // +schema:id=URL  - Override the struct's $id\n")
+		fmt.Fprintf(os.Stderr, "  // +schema:additional-properties=Field - Allow extra properties typed like the catch-all map Field\n")
 	}
 
 	flag.Parse()
 
+	// Informational flags need no further configuration
+	if cfg.HelpValidators {
+		return cfg, nil
+	}
+
+	if err := mergeDirective(cfg); err != nil {
+		return nil, err
+	}
+
 	// Validate required flags
 	if cfg.OutputDir == "" {
 		return nil, fmt.Errorf("--output-dir is required")
@@ -56,10 +192,275 @@ func Parse() (*Config, error) {
 	}
 
 	// Validate tag
-	validTags := map[string]bool{"json": true, "yaml": true, "mapstructure": true, "xml": true}
+	validTags := map[string]bool{"json": true, "yaml": true, "mapstructure": true, "xml": true, "form": true, "query": true}
 	if !validTags[cfg.NameTag] {
-		return nil, fmt.Errorf("invalid tag %q: must be one of json, yaml, mapstructure, xml", cfg.NameTag)
+		return nil, fmt.Errorf("invalid tag %q: must be one of json, yaml, mapstructure, xml, form, query", cfg.NameTag)
+	}
+
+	// Validate property case
+	validPropertyCases := map[string]bool{"camel": true, "snake": true, "pascal": true, "original": true}
+	if !validPropertyCases[cfg.PropertyCase] {
+		return nil, fmt.Errorf("invalid property-case %q: must be one of camel, snake, pascal, original", cfg.PropertyCase)
+	}
+
+	// Validate input format
+	if cfg.InputFormat != "go" && cfg.InputFormat != "proto" {
+		return nil, fmt.Errorf("invalid input-format %q: must be go or proto", cfg.InputFormat)
+	}
+	if cfg.InputFormat == "proto" && cfg.PackageMode {
+		return nil, fmt.Errorf("--package requires --input-format go")
+	}
+
+	// Validate output grouping
+	if cfg.GroupOutputBy != "type" && cfg.GroupOutputBy != "file" {
+		return nil, fmt.Errorf("invalid group-output-by %q: must be type or file", cfg.GroupOutputBy)
+	}
+	// Grouped schemas are always rewritten and have no per-type files to list
+	if cfg.GroupOutputBy == "file" {
+		switch {
+		case cfg.RegistryFile:
+			return nil, fmt.Errorf("--registry-file requires --group-output-by type")
+		case cfg.Incremental:
+			return nil, fmt.Errorf("--incremental requires --group-output-by type")
+		case cfg.Since != "":
+			return nil, fmt.Errorf("--since requires --group-output-by type")
+		}
+	}
+
+	// Validate embed mode
+	if cfg.EmbedMode != "flatten" && cfg.EmbedMode != "ref" {
+		return nil, fmt.Errorf("invalid embed-mode %q: must be flatten or ref", cfg.EmbedMode)
+	}
+
+	// Validate validation tags
+	validValidationTags := map[string]bool{"validate": true, "binding": true}
+	for _, tag := range strings.Split(*tagPriority, ",") {
+		tag = strings.TrimSpace(tag)
+		if !validValidationTags[tag] {
+			return nil, fmt.Errorf("invalid tag-priority entry %q: must be validate or binding", tag)
+		}
+		cfg.TagPriority = append(cfg.TagPriority, tag)
+	}
+
+	if cfg.SchemaURI != "" {
+		if u, err := url.Parse(cfg.SchemaURI); err != nil || u.Scheme == "" || u.Host == "" {
+			return nil, fmt.Errorf("invalid schema-uri %q: must be an absolute URL", cfg.SchemaURI)
+		}
+	}
+
+	// Validate ref style
+	validRefStyles := map[string]bool{"file": true, "defs": true, "id": true}
+	if !validRefStyles[cfg.RefStyle] {
+		return nil, fmt.Errorf("invalid ref-style %q: must be one of file, defs, id", cfg.RefStyle)
+	}
+	if cfg.RefStyle == "id" && cfg.SchemaID == "" {
+		return nil, fmt.Errorf("--ref-style id requires --schema-id")
+	}
+	// Grouped schemas always bundle references under $defs
+	if cfg.GroupOutputBy == "file" && (cfg.RefStyle == "id" || cfg.RefStyle == "file" && isFlagSet("ref-style")) {
+		return nil, fmt.Errorf("--ref-style %s requires --group-output-by type", cfg.RefStyle)
+	}
+
+	if cfg.MaxErrors < 0 {
+		return nil, fmt.Errorf("invalid max-errors %d: must not be negative", cfg.MaxErrors)
+	}
+
+	if cfg.HoistThreshold < 0 {
+		return nil, fmt.Errorf("invalid hoist-threshold %d: must not be negative", cfg.HoistThreshold)
+	}
+
+	// Collect build tags
+	cfg.BuildTags = splitList(*buildTags)
+
+	// Collect extra comment directives
+	cfg.CommentDirectives = splitList(*commentDirectives)
+
+	// Collect type filters
+	cfg.Only = splitList(*only)
+	cfg.Skip = splitList(*skip)
+
+	// Compile excluded field patterns
+	if patterns := splitPatterns(*excludeFields); len(patterns) > 0 {
+		re, err := regexp.Compile("^(?:" + strings.Join(patterns, "|") + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("invalid exclude-fields %q: %w", *excludeFields, err)
+		}
+		cfg.ExcludeFields = re
+	}
+
+	// Compile read-only field patterns
+	if patterns := splitPatterns(*readOnlyFields); len(patterns) > 0 {
+		re, err := regexp.Compile("^(?:" + strings.Join(patterns, "|") + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("invalid read-only-fields %q: %w", *readOnlyFields, err)
+		}
+		cfg.ReadOnlyFields = re
+	}
+
+	// Parse external type mappings
+	typeMappings, err := parser.ParseTypeMappings(*typeMap)
+	if err != nil {
+		return nil, err
+	}
+	cfg.TypeMappings = typeMappings
+
+	// Normalize extension
+	if cfg.Extension == "" || cfg.Extension == "." {
+		return nil, fmt.Errorf("invalid extension %q: must not be empty", cfg.Extension)
+	}
+	if !strings.HasPrefix(cfg.Extension, ".") {
+		cfg.Extension = "." + cfg.Extension
+	}
+
+	// Validate output formats
+	validFormats := map[string]bool{"json": true, "yaml": true}
+	for _, format := range splitList(*formats) {
+		if !validFormats[format] {
+			return nil, fmt.Errorf("invalid format %q: must be json or yaml", format)
+		}
+		if !slices.Contains(cfg.Formats, format) {
+			cfg.Formats = append(cfg.Formats, format)
+		}
+	}
+	if len(cfg.Formats) == 0 {
+		return nil, fmt.Errorf("--format must list at least one format")
+	}
+
+	// Validate marshaler fallback
+	validMarshalerAs := map[string]bool{"any": true, "string": true, "object": true, "number": true, "integer": true, "boolean": true, "array": true}
+	if !validMarshalerAs[cfg.MarshalerAs] {
+		return nil, fmt.Errorf("invalid marshaler-as %q: must be one of any, string, object, number, integer, boolean, array", cfg.MarshalerAs)
+	}
+
+	// Validate output base
+	if cfg.OutputRelativeTo != "cwd" && cfg.OutputRelativeTo != "file" {
+		return nil, fmt.Errorf("invalid output-relative-to %q: must be cwd or file", cfg.OutputRelativeTo)
+	}
+
+	// Validate OpenAPI version
+	if cfg.OpenAPIVersion != "" && cfg.OpenAPIVersion != "3.0" && cfg.OpenAPIVersion != "3.1" {
+		return nil, fmt.Errorf("invalid openapi version %q: must be 3.0 or 3.1", cfg.OpenAPIVersion)
 	}
 
 	return cfg, nil
 }
+
+// isFlagSet reports whether a flag was given on the command line or in a
+// merged go:generate directive.
+func isFlagSet(name string) bool {
+	set := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
+
+// splitList splits a comma-separated flag value, dropping empty entries.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// splitPatterns splits a comma-separated list of regular expressions.
+// Commas inside {m,n} quantifiers or [...] classes and escaped commas (\,)
+// belong to the pattern.
+func splitPatterns(value string) []string {
+	var items []string
+	var current strings.Builder
+	inBraces, inClass, escaped := false, false, false
+
+	for _, ch := range value {
+		switch {
+		case escaped:
+			escaped = false
+		case ch == '\\':
+			escaped = true
+		case inClass:
+			inClass = ch != ']'
+		case ch == '[':
+			inClass = true
+		case ch == '{':
+			inBraces = true
+		case ch == '}':
+			inBraces = false
+		case ch == ',' && !inBraces:
+			if item := strings.TrimSpace(current.String()); item != "" {
+				items = append(items, item)
+			}
+			current.Reset()
+			continue
+		}
+		current.WriteRune(ch)
+	}
+	if item := strings.TrimSpace(current.String()); item != "" {
+		items = append(items, item)
+	}
+	return items
+}
+
+// mergeDirective applies the flags of a //go:generate json-schema-gen directive
+// found in the single input directory. Flags given on the command line take
+// precedence, and a relative --output-dir from the directive is resolved
+// against the directive's directory (where go generate would run it).
+func mergeDirective(cfg *Config) error {
+	if flag.NArg() > 1 {
+		return nil // Directives describe a single package
+	}
+	dir := "."
+	if flag.NArg() == 1 {
+		dir = flag.Arg(0)
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return nil
+	}
+
+	args, err := directiveArgs(dir, splitList(flag.Lookup("build-tags").Value.String()))
+	if err != nil || args == nil {
+		return err
+	}
+
+	explicit := make(map[string]string)
+	flag.Visit(func(f *flag.Flag) {
+		explicit[f.Name] = f.Value.String()
+	})
+
+	// Parse the directive into the same flag values, leaving the command
+	// line's paths in place
+	directiveFlags := flag.NewFlagSet("go:generate", flag.ContinueOnError)
+	directiveFlags.SetOutput(io.Discard)
+	flag.VisitAll(func(f *flag.Flag) {
+		directiveFlags.Var(f.Value, f.Name, f.Usage)
+	})
+	if err := directiveFlags.Parse(args); err != nil {
+		return fmt.Errorf("go:generate directive in %s: %w", dir, err)
+	}
+
+	// Mark the directive's flags as set, so explicit flags are detected
+	// wherever they were given, then let the command line's flags win
+	var setErr error
+	directiveFlags.Visit(func(f *flag.Flag) {
+		if _, ok := explicit[f.Name]; !ok && setErr == nil {
+			setErr = flag.Set(f.Name, f.Value.String())
+		}
+	})
+	if setErr != nil {
+		return setErr
+	}
+	for name, value := range explicit {
+		if err := flag.Set(name, value); err != nil {
+			return err
+		}
+	}
+	if _, ok := explicit["output-dir"]; !ok && cfg.OutputDir != "" && !filepath.IsAbs(cfg.OutputDir) &&
+		cfg.OutputRelativeTo != "file" {
+		cfg.OutputDir = filepath.Join(dir, cfg.OutputDir)
+	}
+	return nil
+}
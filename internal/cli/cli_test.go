@@ -0,0 +1,26 @@
+package cli
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSplitPatterns(t *testing.T) {
+	tests := []struct {
+		value string
+		want  []string
+	}{
+		{value: "Internal.*, debug,", want: []string{"Internal.*", "debug"}},
+		{value: "x_[a-z]{2,4},id", want: []string{"x_[a-z]{2,4}", "id"}},
+		{value: "a[,;]b,c", want: []string{"a[,;]b", "c"}},
+		{value: `tag\,name,other`, want: []string{`tag\,name`, "other"}},
+		{value: "", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := splitPatterns(tt.value); !slices.Equal(got, tt.want) {
+				t.Errorf("patterns = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
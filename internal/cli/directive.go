@@ -0,0 +1,141 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"go/build"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// toolName is the command name recognized in //go:generate directives.
+const toolName = "json-schema-gen"
+
+// directive is a //go:generate directive running json-schema-gen.
+type directive struct {
+	pos  string   // file:line of the directive
+	args []string // Arguments following the command
+}
+
+// directiveArgs returns the flags of the //go:generate directive running
+// json-schema-gen in the Go files of dir that go generate would consider
+// (no _test.go files, build constraints satisfied with the given tags), or
+// nil if there is none. Several directives are ambiguous and an error.
+func directiveArgs(dir string, buildTags []string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read directory %s: %w", dir, err)
+	}
+
+	ctx := build.Default
+	ctx.BuildTags = buildTags
+
+	var directives []directive
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		match, err := ctx.MatchFile(dir, name)
+		if err != nil {
+			return nil, err
+		}
+		if !match {
+			continue
+		}
+		found, err := fileDirectives(filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+		directives = append(directives, found...)
+	}
+
+	switch len(directives) {
+	case 0:
+		return nil, nil
+	case 1:
+		return directives[0].args, nil
+	}
+	positions := make([]string, len(directives))
+	for i, d := range directives {
+		positions[i] = d.pos
+	}
+	return nil, fmt.Errorf("several %s go:generate directives in %s (%s); pass the flags on the command line instead",
+		toolName, dir, strings.Join(positions, ", "))
+}
+
+// fileDirectives scans a file for json-schema-gen //go:generate directives.
+func fileDirectives(path string) ([]directive, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	var directives []directive
+	scanner := bufio.NewScanner(f)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line, ok := strings.CutPrefix(scanner.Text(), "//go:generate ")
+		if !ok {
+			continue
+		}
+		words, err := splitDirective(line)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: go:generate directive: %w", path, lineNo, err)
+		}
+		if args, ok := toolArgs(words); ok {
+			directives = append(directives, directive{pos: fmt.Sprintf("%s:%d", path, lineNo), args: args})
+		}
+	}
+	return directives, scanner.Err()
+}
+
+// toolArgs returns the arguments following the json-schema-gen command,
+// invoked either directly or via "go run|tool <module>/json-schema-gen[@version]".
+func toolArgs(words []string) ([]string, bool) {
+	if len(words) > 0 && words[0] == toolName {
+		return words[1:], true
+	}
+	if len(words) > 2 && words[0] == "go" && (words[1] == "run" || words[1] == "tool") {
+		pkg, _, _ := strings.Cut(words[2], "@")
+		if pkg == toolName || strings.HasSuffix(pkg, "/"+toolName) {
+			return words[3:], true
+		}
+	}
+	return nil, false
+}
+
+// splitDirective splits a directive into words like go generate does:
+// on whitespace, with double-quoted Go strings kept as single words.
+func splitDirective(line string) ([]string, error) {
+	var words []string
+	for {
+		line = strings.TrimLeft(line, " \t")
+		if line == "" {
+			return words, nil
+		}
+
+		if line[0] != '"' {
+			end := strings.IndexAny(line, " \t")
+			if end < 0 {
+				end = len(line)
+			}
+			words = append(words, line[:end])
+			line = line[end:]
+			continue
+		}
+
+		quoted, err := strconv.QuotedPrefix(line)
+		if err != nil {
+			return nil, fmt.Errorf("unterminated quoted string")
+		}
+		word, err := strconv.Unquote(quoted)
+		if err != nil {
+			return nil, err
+		}
+		words = append(words, word)
+		line = line[len(quoted):]
+	}
+}
@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSplitDirective(t *testing.T) {
+	tests := []struct {
+		line    string
+		want    []string
+		wantErr bool
+	}{
+		{line: "json-schema-gen --output-dir schemas", want: []string{"json-schema-gen", "--output-dir", "schemas"}},
+		{line: " \tjson-schema-gen\t --tag  yaml ", want: []string{"json-schema-gen", "--tag", "yaml"}},
+		{line: `json-schema-gen --schema-id "https://example.com/my schemas"`, want: []string{"json-schema-gen", "--schema-id", "https://example.com/my schemas"}},
+		{line: `json-schema-gen --strip-prefix "\"x\"\t"`, want: []string{"json-schema-gen", "--strip-prefix", "\"x\"\t"}},
+		{line: "", want: nil},
+		{line: `json-schema-gen --tag "yaml`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			got, err := splitDirective(tt.line)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, want error %v", err, tt.wantErr)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("words = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToolArgs(t *testing.T) {
+	tests := []struct {
+		words []string
+		want  []string
+		ok    bool
+	}{
+		{words: []string{"json-schema-gen", "--output-dir", "schemas"}, want: []string{"--output-dir", "schemas"}, ok: true},
+		{words: []string{"json-schema-gen"}, want: []string{}, ok: true},
+		{words: []string{"go", "run", "github.com/ron96g/json-schema-gen@v1.2.3", "--tag", "yaml"}, want: []string{"--tag", "yaml"}, ok: true},
+		{words: []string{"go", "tool", "json-schema-gen", "--recursive"}, want: []string{"--recursive"}, ok: true},
+		{words: []string{"go", "run", "example.com/other-json-schema-gen"}},
+		{words: []string{"go", "build", "json-schema-gen"}},
+		{words: []string{"stringer", "-type", "Status"}},
+		{words: []string{"go", "run"}},
+	}
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.words, " "), func(t *testing.T) {
+			got, ok := toolArgs(tt.words)
+			if ok != tt.ok || !slices.Equal(got, tt.want) {
+				t.Errorf("toolArgs = %q, %v, want %q, %v", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+// TestDirectiveArgs checks that only directives go generate would run are
+// considered, and that several of them are rejected.
+func TestDirectiveArgs(t *testing.T) {
+	dir := filepath.Join("testdata", "directive")
+
+	args, err := directiveArgs(dir, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"--output-dir", "schemas", "--tag", "yaml"}; !slices.Equal(args, want) {
+		t.Errorf("args = %q, want %q", args, want)
+	}
+
+	// The enterprise directive is built with its tag, next to the untagged one
+	if _, err := directiveArgs(dir, []string{"enterprise"}); err == nil || !strings.Contains(err.Error(), "several") {
+		t.Errorf("error with enterprise tag = %v, want several directives", err)
+	}
+
+	_, err = directiveArgs(filepath.Join("testdata", "ambiguous"), nil)
+	if err == nil || !strings.Contains(err.Error(), "a.go:3") || !strings.Contains(err.Error(), "b.go:3") {
+		t.Errorf("error = %v, want both directive positions", err)
+	}
+}
@@ -0,0 +1,5 @@
+//go:build enterprise
+
+package models
+
+//go:generate json-schema-gen --output-dir enterprise
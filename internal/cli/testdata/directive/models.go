@@ -0,0 +1,3 @@
+package models
+
+//go:generate go run github.com/ron96g/json-schema-gen@latest --output-dir schemas --tag yaml
@@ -0,0 +1,3 @@
+package models
+
+//go:generate json-schema-gen --output-dir testschemas
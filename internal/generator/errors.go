@@ -0,0 +1,50 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ErrorList aggregates errors when generation keeps going after failures.
+type ErrorList struct {
+	errs []error
+	max  int // Maximum number of errors listed in the message (0 for no limit)
+}
+
+// NewErrorList creates an ErrorList listing at most max errors (0 for no limit).
+func NewErrorList(max int) *ErrorList {
+	return &ErrorList{max: max}
+}
+
+// Add records an error.
+func (l *ErrorList) Add(err error) {
+	l.errs = append(l.errs, err)
+}
+
+// Len returns the number of recorded errors.
+func (l *ErrorList) Len() int {
+	return len(l.errs)
+}
+
+// Err returns the combined error, or nil if no errors were recorded.
+// Errors beyond the limit are summarized as "and N more".
+func (l *ErrorList) Err() error {
+	if len(l.errs) == 0 {
+		return nil
+	}
+
+	shown := l.errs
+	if l.max > 0 && len(shown) > l.max {
+		shown = shown[:l.max]
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d error(s) occurred:", len(l.errs))
+	for _, err := range shown {
+		fmt.Fprintf(&b, "\n  - %v", err)
+	}
+	if more := len(l.errs) - len(shown); more > 0 {
+		fmt.Fprintf(&b, "\n  and %d more", more)
+	}
+	return fmt.Errorf("%s", b.String())
+}
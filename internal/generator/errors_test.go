@@ -0,0 +1,58 @@
+package generator
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+)
+
+func TestErrorList(t *testing.T) {
+	tests := []struct {
+		name  string
+		max   int
+		count int
+		want  string
+	}{
+		{name: "empty", max: 2, count: 0, want: ""},
+		{name: "below limit", max: 2, count: 1, want: "1 error(s) occurred:\n  - error 1"},
+		{name: "at limit", max: 2, count: 2, want: "2 error(s) occurred:\n  - error 1\n  - error 2"},
+		{name: "above limit", max: 2, count: 5, want: "5 error(s) occurred:\n  - error 1\n  - error 2\n  and 3 more"},
+		{name: "no limit", max: 0, count: 3, want: "3 error(s) occurred:\n  - error 1\n  - error 2\n  - error 3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := NewErrorList(tt.max)
+			for i := range tt.count {
+				errs.Add(fmt.Errorf("error %d", i+1))
+			}
+			if errs.Len() != tt.count {
+				t.Errorf("Len() = %d, want %d", errs.Len(), tt.count)
+			}
+
+			err := errs.Err()
+			if tt.want == "" {
+				if err != nil {
+					t.Errorf("Err() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("Err() = %q, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+// TestKeepGoingMaxErrors checks that generation with KeepGoing reports every
+// failing type, capped by MaxErrors.
+func TestKeepGoingMaxErrors(t *testing.T) {
+	g := NewGenerator(Config{OutputDir: t.TempDir(), KeepGoing: true, MaxErrors: 2})
+	err := g.GenerateFromPaths([]string{filepath.Join("testdata", "aliases")})
+	want := "3 error(s) occurred:\n" +
+		"  - alias A: target struct \"MissingA\" not found\n" +
+		"  - alias B: target struct \"MissingB\" not found\n" +
+		"  and 1 more"
+	if err == nil || err.Error() != want {
+		t.Errorf("error = %v, want %q", err, want)
+	}
+}
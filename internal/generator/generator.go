@@ -3,55 +3,308 @@ package generator
 
 import (
 	"fmt"
+	"maps"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/ron96g/json-schema-gen/internal/parser"
+	"github.com/ron96g/json-schema-gen/internal/protobuf"
 	"github.com/ron96g/json-schema-gen/internal/schema"
 )
 
+const (
+	// InputFormatGo parses annotated structs of Go source files.
+	InputFormatGo = "go"
+	// InputFormatProto parses messages of Protocol Buffers definitions.
+	InputFormatProto = "proto"
+
+	// GroupByType writes one schema file per type.
+	GroupByType = "type"
+	// GroupByFile writes one schema file per source file, bundling its
+	// annotated structs under $defs.
+	GroupByFile = "file"
+)
+
+// sourceParser parses input files into the struct model consumed by the builder.
+type sourceParser interface {
+	ParsePathWithOptions(path string, recursive bool) ([]parser.StructInfo, error)
+	FindStructByName(path string, name string, recursive bool) (*parser.StructInfo, error)
+	LookupStruct(name string) *parser.StructInfo
+	Marshalers() map[string]parser.MarshalerKind
+	Enums() map[string][]parser.EnumValue
+	SourceFiles(dir string) ([]string, error)
+}
+
 // Generator orchestrates the parsing and schema generation process.
 type Generator struct {
-	parser    *parser.Parser
-	builder   *schema.Builder
-	writer    *Writer
-	outputDir string
-	recursive bool
+	parser        sourceParser
+	builder       *schema.Builder
+	writer        *Writer
+	outputDir     string
+	extension     string   // JSON schema file extension; other formats derive theirs from it
+	formats       []string // Output formats written for every schema
+	recursive     bool
+	packageMode   bool
+	buildTags     []string
+	warnings      *WarningCollector
+	failOnWarning bool
+	flatten       bool // Refs to single-field wrappers are flattened once all structs are known
+	flattenEmbeds bool // Embedded struct fields are promoted once all structs are known
+	incremental   bool
+	modTimes      map[string]time.Time // Cached source file modification times for incremental mode
+	dirFiles      map[string][]string  // Cached Go source files per directory for incremental mode and --since
+	since         string               // Git ref; only types with sources changed since are generated
+	keepGoing     bool
+	maxErrors     int
+	only          map[string]bool // If set, only these types (and their ref'd deps) are written
+	skip          map[string]bool // Annotated types not generated unless needed as a dependency
+	bundleRefs    bool            // Referenced types are bundled into $defs instead of written as files
+	tsEnums       bool            // Extracted enums are also written as TypeScript union types
+	registry      bool            // A registry schema referencing every generated schema is written
+	groupByFile   bool            // Annotated structs are written as one schema per source file
+
+	changedFiles func(dir, ref string) (map[string]bool, error) // Files changed since a git ref (replaceable in tests)
 }
 
 // Config holds generator configuration.
 type Config struct {
-	OutputDir string
-	NameTag   string // Tag for property names (json, yaml, etc.)
-	SchemaID  string // Base URL for $id field
-	Recursive bool   // Recursively scan directories
+	OutputDir          string
+	NameTag            string                        // Tag for property names (json, yaml, etc.)
+	SchemaID           string                        // Base URL for $id field
+	SchemaURI          string                        // Root $schema value, overriding the draft default
+	Recursive          bool                          // Recursively scan directories
+	CrossModule        bool                          // Descend into nested modules when scanning recursively
+	PropertyCase       string                        // Case of property names for untagged fields
+	StripPrefix        string                        // Prefix removed from all property names
+	QualifyRefs        bool                          // Prefix schema files and refs with the package (pkg_config.schema.json)
+	CommentDirectives  []string                      // Extra comment prefixes dropped from descriptions
+	PreserveNewlines   bool                          // Keep line breaks and paragraphs of comments in descriptions
+	IncludeTests       bool                          // Also parse _test.go files in directories
+	IncludeTestdata    bool                          // Descend into testdata directories when scanning recursively
+	IncludeVendor      bool                          // Descend into vendor directories when scanning recursively
+	PackageMode        bool                          // Treat paths as Go package patterns
+	OpenAPIVersion     string                        // OpenAPI version for nullable pointers
+	NullablePointers   bool                          // Add "null" to the type of pointer fields
+	FlattenSingleField bool                          // Replace refs to single-field structs with the field's schema
+	HumanizeTitles     bool                          // Humanize struct names for the title field
+	OutputRelativeTo   string                        // Base for a relative OutputDir (cwd or file)
+	FailOnWarning      bool                          // Return an error if any warnings were reported
+	ValidationTags     []string                      // Tags to read validator rules from, in priority order
+	IntrinsicBounds    bool                          // Emit minimum/maximum for sized integer types
+	RuneAsString       bool                          // Emit standalone rune fields as single-character strings
+	HoistThreshold     int                           // Hoist inline types referenced at least N times into $defs
+	BuildTags          []string                      // Build tags for evaluating //go:build constraints
+	MarshalerAs        string                        // Schema type for json.Marshaler types
+	TypeMappings       map[string]parser.TypeMapping // External types mapped to primitives
+	Extension          string                        // Schema file extension (default ".schema.json")
+	Formats            []string                      // Output formats written for every schema (default json)
+	BaseRef            string                        // Base schema every root schema extends via allOf
+	DocumentedEnums    bool                          // Emit commented enum values as oneOf const+description
+	EnumVarnames       bool                          // Emit constant names of enums as x-enum-varnames
+	EnumNames          bool                          // Emit labels derived from constant names as x-enumNames
+	RefStyle           string                        // How $refs point to other schemas (file, defs or id)
+	KeepGoing          bool                          // Continue past per-type errors and report them together
+	MaxErrors          int                           // Maximum number of errors listed with KeepGoing (0 for no limit)
+	Only               []string                      // Only write these types and the dependencies they reference
+	Skip               []string                      // Annotated types to exclude (still written if referenced via $ref)
+	Stamp              string                        // Generator name and version written as x-generator (empty to disable)
+	NoRequired         bool                          // Suppress required arrays
+	Incremental        bool                          // Skip schemas whose outputs are newer than their sources and dependencies
+	EmbedMode          string                        // Embedded structs are flattened (default) or composed via allOf
+	Since              string                        // Git ref; only regenerate types whose sources changed since
+	RequiredNonEmpty   bool                          // Required slices and maps get minItems/minProperties 1
+	TitleFromComment   bool                          // Use the first doc comment sentence as title
+	ExamplesFromEnum   bool                          // Emit the first enum value of fields as example
+	ReadOnlyFields     *regexp.Regexp                // Fields marked readOnly, matched by property or Go name
+	WarnUnmappedTypes  bool                          // Warn about external types emitted as a bare object
+	AutoFieldTitles    bool                          // Title fields with their humanized property name
+	InputFormat        string                        // Format of the input files (go or proto)
+	EmitTSEnums        bool                          // Write a .ts union type for every extracted enum
+	DeterministicMaps  bool                          // Constrain enum and integer map keys via propertyNames
+	ExcludeFields      *regexp.Regexp                // Fields dropped from all schemas, matched by property or Go name
+	RegistryFile       bool                          // Write index.schema.json with $refs to every generated schema
+	AddSchemaComment   bool                          // Record the source file as $comment of root schemas
+	GroupOutputBy      string                        // Write one schema per type (default) or per source file; excludes RegistryFile, Incremental and Since
+
+	RequiredUnlessOmitEmpty bool // Require all fields except pointers and omitempty fields
+	RequiredStringsNonEmpty bool // Required strings get minLength 1
 }
 
 // NewGenerator creates a new Generator.
 func NewGenerator(cfg Config) *Generator {
+	warnings := &WarningCollector{}
+	p := parser.NewParser(parser.Config{
+		NameTag:           cfg.NameTag,
+		BuildTags:         cfg.BuildTags,
+		TypeMappings:      cfg.TypeMappings,
+		CrossModule:       cfg.CrossModule,
+		PropertyCase:      cfg.PropertyCase,
+		StripPrefix:       cfg.StripPrefix,
+		QualifyNames:      cfg.QualifyRefs,
+		ExcludeFields:     cfg.ExcludeFields,
+		CommentDirectives: cfg.CommentDirectives,
+		PreserveNewlines:  cfg.PreserveNewlines,
+		IncludeTests:      cfg.IncludeTests,
+		IncludeTestdata:   cfg.IncludeTestdata,
+		IncludeVendor:     cfg.IncludeVendor,
+	})
+	p.SetWarnFunc(warnings.Warnf)
+
+	var source sourceParser = p
+	if cfg.InputFormat == InputFormatProto {
+		source = protobuf.NewParser()
+	}
+
+	// Grouped schemas reference the structs they bundle via $defs
+	groupByFile := cfg.GroupOutputBy == GroupByFile
+	refStyle := cfg.RefStyle
+	if groupByFile {
+		refStyle = schema.RefStyleDefs
+	}
+
+	b := schema.NewBuilder(schema.Config{
+		SchemaID:           cfg.SchemaID,
+		SchemaURI:          cfg.SchemaURI,
+		OpenAPIVersion:     cfg.OpenAPIVersion,
+		HumanizeTitles:     cfg.HumanizeTitles,
+		ValidationTags:     cfg.ValidationTags,
+		IntrinsicBounds:    cfg.IntrinsicBounds,
+		RuneAsString:       cfg.RuneAsString,
+		HoistThreshold:     cfg.HoistThreshold,
+		MarshalerAs:        cfg.MarshalerAs,
+		Extension:          cfg.Extension,
+		BaseRef:            cfg.BaseRef,
+		DocumentedEnums:    cfg.DocumentedEnums,
+		EnumVarnames:       cfg.EnumVarnames,
+		EnumNames:          cfg.EnumNames,
+		RefStyle:           refStyle,
+		NullablePointers:   cfg.NullablePointers,
+		FlattenSingleField: cfg.FlattenSingleField,
+		Stamp:              cfg.Stamp,
+		NoRequired:         cfg.NoRequired,
+		EmbedMode:          cfg.EmbedMode,
+		RequiredNonEmpty:   cfg.RequiredNonEmpty,
+		TitleFromComment:   cfg.TitleFromComment,
+		ExamplesFromEnum:   cfg.ExamplesFromEnum,
+		ReadOnlyFields:     cfg.ReadOnlyFields,
+		WarnUnmappedTypes:  cfg.WarnUnmappedTypes,
+		AutoFieldTitles:    cfg.AutoFieldTitles,
+		DeterministicMaps:  cfg.DeterministicMaps,
+		SchemaComment:      cfg.AddSchemaComment,
+
+		RequiredUnlessOmitEmpty: cfg.RequiredUnlessOmitEmpty,
+		RequiredStringsNonEmpty: cfg.RequiredStringsNonEmpty,
+	})
+	b.SetWarnFunc(warnings.Warnf)
+	if cfg.InputFormat == InputFormatProto {
+		b.RegisterTypeHandler(protobuf.TypeHandler)
+	}
+
+	formats := cfg.Formats
+	if len(formats) == 0 {
+		formats = []string{FormatJSON}
+	}
+
 	return &Generator{
-		parser:    parser.NewParser(cfg.NameTag),
-		builder:   schema.NewBuilder(cfg.SchemaID),
-		writer:    NewWriter(cfg.OutputDir),
-		outputDir: cfg.OutputDir,
-		recursive: cfg.Recursive,
+		parser:        source,
+		builder:       b,
+		writer:        NewWriter(cfg.OutputDir, cfg.OutputRelativeTo, cfg.Extension),
+		outputDir:     cfg.OutputDir,
+		extension:     cfg.Extension,
+		formats:       formats,
+		recursive:     cfg.Recursive,
+		packageMode:   cfg.PackageMode,
+		buildTags:     cfg.BuildTags,
+		warnings:      warnings,
+		failOnWarning: cfg.FailOnWarning,
+		flatten:       cfg.FlattenSingleField,
+		flattenEmbeds: cfg.EmbedMode != schema.EmbedModeRef,
+		incremental:   cfg.Incremental,
+		modTimes:      make(map[string]time.Time),
+		dirFiles:      make(map[string][]string),
+		since:         cfg.Since,
+		changedFiles:  changedFiles,
+		keepGoing:     cfg.KeepGoing,
+		maxErrors:     cfg.MaxErrors,
+		only:          toSet(cfg.Only),
+		skip:          toSet(cfg.Skip),
+		bundleRefs:    refStyle == schema.RefStyleDefs,
+		tsEnums:       cfg.EmitTSEnums,
+		registry:      cfg.RegistryFile,
+		groupByFile:   groupByFile,
 	}
 }
 
+// RegisterTypeHandler registers a custom schema handler for field and
+// collection element types, consulted before the built-in type mapping.
+func (g *Generator) RegisterTypeHandler(handler schema.TypeHandler) {
+	g.builder.RegisterTypeHandler(handler)
+}
+
 // GenerateFromPaths generates schemas from the given paths.
+// In package mode, paths are package patterns resolved through the module graph.
+// Otherwise, paths that do not exist but contain wildcards are expanded as globs.
 func (g *Generator) GenerateFromPaths(paths []string) error {
+	if g.packageMode {
+		dirs, err := resolvePackagePaths(paths, g.buildTags)
+		if err != nil {
+			return err
+		}
+		paths = dirs
+	} else {
+		expanded, err := expandGlobs(paths)
+		if err != nil {
+			return err
+		}
+		paths = expanded
+	}
+
+	errs := NewErrorList(g.maxErrors)
+	failed := make(map[string]bool) // Types whose errors were already recorded
+
 	// Parse all paths to collect annotated structs
 	var allStructs []parser.StructInfo
 	for _, path := range paths {
 		structs, err := g.parser.ParsePathWithOptions(path, g.recursive)
 		if err != nil {
-			return fmt.Errorf("parse %s: %w", path, err)
+			if err := g.handleError(errs, fmt.Errorf("parse %s: %w", path, err)); err != nil {
+				return err
+			}
+			continue
 		}
 		allStructs = append(allStructs, structs...)
 	}
 
 	if len(allStructs) == 0 {
+		if err := errs.Err(); err != nil {
+			return err
+		}
 		return fmt.Errorf("no exported structs found in paths: %v", paths)
 	}
 
+	// Types with custom marshalers get a fallback schema instead of field introspection
+	g.builder.SetMarshalers(g.parser.Marshalers())
+	g.builder.SetEnums(g.parser.Enums())
+
+	// Resolve annotated struct aliases (type A = B) to their target's fields
+	for i, s := range allStructs {
+		if s.AliasOf == "" {
+			continue
+		}
+		resolved, err := g.resolveAlias(s, allStructs, paths)
+		if err != nil {
+			if err := g.handleError(errs, err); err != nil {
+				return err
+			}
+			failed[s.Name] = true
+			continue
+		}
+		allStructs[i] = resolved
+	}
+
 	// Build struct lookup map and track annotated structs
 	structMap := make(map[string]parser.StructInfo)
 	annotatedStructs := make(map[string]bool) // Structs with +schema annotation
@@ -65,9 +318,16 @@ func (g *Generator) GenerateFromPaths(paths []string) error {
 	allRefs := make(map[string]bool)
 
 	for _, structInfo := range allStructs {
+		if failed[structInfo.Name] {
+			continue
+		}
 		_, refs, err := g.builder.BuildSchemaWithRefs(structInfo)
 		if err != nil {
-			return fmt.Errorf("analyze refs for %s: %w", structInfo.Name, err)
+			if err := g.handleError(errs, fmt.Errorf("analyze refs for %s: %w", structInfo.Name, err)); err != nil {
+				return err
+			}
+			failed[structInfo.Name] = true
+			continue
 		}
 		for _, ref := range refs {
 			depGraph.AddDependency(structInfo.Name, ref)
@@ -97,9 +357,17 @@ func (g *Generator) GenerateFromPaths(paths []string) error {
 			// Search for the struct in all paths
 			refStruct := g.findReferencedStruct(ref, paths)
 			if refStruct == nil {
-				fmt.Printf("Warning: referenced type %q not found in parsed files\n", ref)
+				g.warnings.Warnf("referenced type %q not found in parsed files", ref)
 				continue
 			}
+			if refStruct.AliasOf != "" {
+				resolvedAlias, err := g.resolveAlias(*refStruct, allStructs, paths)
+				if err != nil {
+					g.warnings.Warnf("%v", err)
+					continue
+				}
+				refStruct = &resolvedAlias
+			}
 
 			// Add to structMap and allStructs (but NOT to annotatedStructs)
 			structMap[ref] = *refStruct
@@ -108,7 +376,7 @@ func (g *Generator) GenerateFromPaths(paths []string) error {
 			// Collect refs from the newly resolved struct
 			_, newRefs, err := g.builder.BuildSchemaWithRefs(*refStruct)
 			if err != nil {
-				fmt.Printf("Warning: could not analyze refs for %q: %v\n", ref, err)
+				g.warnings.Warnf("could not analyze refs for %q: %v", ref, err)
 				continue
 			}
 			for _, newRef := range newRefs {
@@ -127,6 +395,31 @@ func (g *Generator) GenerateFromPaths(paths []string) error {
 	// Configure builder with struct map for per-struct inline support
 	g.builder.SetStructMap(structMap)
 
+	// Structs with +schema:file are written to (and referenced by) their custom name
+	filenames, err := g.customFilenames(structMap)
+	if err != nil {
+		return err
+	}
+	g.writer.SetFilenames(filenames)
+
+	// Wrappers and embedded structs can only be flattened once their structs
+	// are known, so recollect the dependencies now that referenced types are resolved
+	if g.flatten || hasFlattenedEmbeds(allStructs, g.flattenEmbeds) {
+		depGraph = schema.NewDependencyGraph()
+		for _, structInfo := range allStructs {
+			if failed[structInfo.Name] {
+				continue
+			}
+			_, refs, err := g.builder.BuildSchemaWithRefs(structInfo)
+			if err != nil {
+				continue // Already reported while resolving refs
+			}
+			for _, ref := range refs {
+				depGraph.AddDependency(structInfo.Name, ref)
+			}
+		}
+	}
+
 	// Check for circular dependencies (applies to both inline and ref modes)
 	if cycle, hasCycle := depGraph.DetectCircular(); hasCycle {
 		return fmt.Errorf("circular dependency detected: %v", cycle)
@@ -144,17 +437,20 @@ func (g *Generator) GenerateFromPaths(paths []string) error {
 		return fmt.Errorf("dependency sort: %w", err)
 	}
 
+	// Select the annotated structs to generate
+	roots := g.selectRoots(annotatedStructs)
+
 	// Track which structs are needed as schema files (referenced via $ref by non-inline structs)
 	// We need to propagate this iteratively: a struct needs a file if it's:
 	// 1. An annotated struct that is NOT inline (uses $ref)
 	// 2. Referenced by another struct that itself needs a file AND is not inline
 	refsNeededAsFiles := make(map[string]bool)
 
-	// Seed with annotated non-inline structs
+	// Seed with selected non-inline structs; bundled refs never need files
 	structsNeedingFiles := make(map[string]bool)
-	for name := range annotatedStructs {
+	for name := range roots {
 		structInfo := structMap[name]
-		if !structInfo.Inline {
+		if !structInfo.Inline && !g.bundleRefs {
 			structsNeedingFiles[name] = true
 		}
 	}
@@ -183,7 +479,18 @@ func (g *Generator) GenerateFromPaths(paths []string) error {
 		}
 	}
 
+	// Outside a git repository, everything is generated
+	var changed map[string]bool
+	if g.since != "" {
+		if changed, err = g.changedFiles(filepath.Dir(allStructs[0].FilePath), g.since); err != nil {
+			g.warnings.Warnf("--since %s: %v; generating all schemas", g.since, err)
+		}
+	}
+
 	// Generate schemas in dependency order
+	var written []string                           // Types with schema files, including up-to-date ones
+	var emitted []string                           // Types with schema files, grouped schemas or parameters
+	groups := make(map[string][]parser.StructInfo) // Grouped structs by source file
 	for _, typeName := range sortedTypes {
 		structInfo, ok := structMap[typeName]
 		if !ok {
@@ -191,28 +498,154 @@ func (g *Generator) GenerateFromPaths(paths []string) error {
 		}
 
 		// Determine if we should generate a schema file for this struct:
-		// 1. Annotated structs (+schema or +schema:inline) always get schema files
-		// 2. Auto-resolved structs only get schema files if referenced via $ref
-		if !annotatedStructs[typeName] && !refsNeededAsFiles[typeName] {
+		// 1. Selected annotated structs (+schema or +schema:inline) always get schema files
+		// 2. Other structs only get schema files if referenced via $ref
+		if !roots[typeName] && !refsNeededAsFiles[typeName] {
+			continue
+		}
+		if failed[typeName] {
+			continue
+		}
+		emitted = append(emitted, typeName)
+		if g.groupByFile && !structInfo.Parameters {
+			groups[structInfo.FilePath] = append(groups[structInfo.FilePath], structInfo)
+			continue
+		}
+		if !structInfo.Parameters {
+			written = append(written, typeName)
+		}
+		if g.registry {
+			if err := g.checkRegistryPath(structInfo); err != nil {
+				return err
+			}
+		}
+		if g.incremental && g.upToDate(typeName, structMap, depGraph) {
+			continue
+		}
+		if changed != nil && g.unchangedSince(changed, typeName, structMap, depGraph) {
 			continue
 		}
 
-		refTracker := schema.NewRefTracker()
-		jsonSchema, err := g.builder.BuildSchema(structInfo, refTracker)
-		if err != nil {
-			return fmt.Errorf("build schema for %s: %w", typeName, err)
+		if err := g.generate(structInfo); err != nil {
+			if err := g.handleError(errs, err); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := g.writeGroups(groups, errs); err != nil {
+		return err
+	}
+
+	if g.tsEnums {
+		if err := g.writeTSEnums(emitted, structMap, depGraph, errs); err != nil {
+			return err
+		}
+	}
+
+	if g.registry {
+		if err := g.writeRegistry(written); err != nil {
+			if err := g.handleError(errs, err); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := errs.Err(); err != nil {
+		return err
+	}
+
+	if g.failOnWarning {
+		if n := len(g.warnings.Warnings()); n > 0 {
+			return fmt.Errorf("%d warning(s) reported with --fail-on-warning", n)
+		}
+	}
+
+	return nil
+}
+
+// selectRoots returns the annotated structs to generate, restricted to the
+// --only set if given and excluding --skip types. Skipped types are still
+// written when a generated schema references them via $ref.
+// Unknown --only names are reported as warnings.
+func (g *Generator) selectRoots(annotated map[string]bool) map[string]bool {
+	if len(g.only) == 0 && len(g.skip) == 0 {
+		return annotated
+	}
+
+	roots := make(map[string]bool)
+	for name := range annotated {
+		if len(g.only) > 0 && !g.only[name] {
+			continue
+		}
+		if g.skip[name] {
+			continue
+		}
+		roots[name] = true
+	}
+	for name := range g.only {
+		if !annotated[name] {
+			g.warnings.Warnf("--only type %q is not an annotated struct", name)
 		}
+	}
+	return roots
+}
+
+// customFilenames returns the +schema:file names by type. Names must be
+// plain filenames and must not collide with each other or with the default
+// filename of another type, since one schema would overwrite the other.
+func (g *Generator) customFilenames(structMap map[string]parser.StructInfo) (map[string]string, error) {
+	filenames := make(map[string]string)
+	owners := make(map[string]string) // Type written to each filename
+	for _, name := range slices.Sorted(maps.Keys(structMap)) {
+		file := structMap[name].File
+		if file == "" {
+			owners[schema.SchemaFilename(name, g.extension)] = name
+			continue
+		}
+		if file == "." || file == ".." || strings.ContainsAny(file, `/\`) {
+			return nil, fmt.Errorf("struct %s: invalid +schema:file %q: must be a filename without path separators", name, file)
+		}
+		filenames[name] = file
+	}
 
-		if err := g.writer.WriteSchema(typeName, jsonSchema); err != nil {
-			return fmt.Errorf("write schema for %s: %w", typeName, err)
+	for _, name := range slices.Sorted(maps.Keys(filenames)) {
+		file := schema.CustomFilename(filenames[name], g.extension)
+		if other, ok := owners[file]; ok {
+			return nil, fmt.Errorf("struct %s: +schema:file %q is also the schema file of %s", name, file, other)
 		}
+		owners[file] = name
+	}
+	return filenames, nil
+}
+
+// toSet converts a list of names to a set.
+func toSet(names []string) map[string]bool {
+	set := make(map[string]bool, len(names))
+	for _, name := range names {
+		set[name] = true
 	}
+	return set
+}
 
+// handleError returns err unless keep-going is enabled, in which case the
+// error is recorded and generation continues.
+func (g *Generator) handleError(errs *ErrorList, err error) error {
+	if !g.keepGoing {
+		return err
+	}
+	errs.Add(err)
 	return nil
 }
 
 // findReferencedStruct searches for a struct definition in the given paths.
 func (g *Generator) findReferencedStruct(name string, paths []string) *parser.StructInfo {
+	// Structs declared in the parsed files are a map lookup
+	if refStruct := g.parser.LookupStruct(name); refStruct != nil {
+		return refStruct
+	}
+
+	// Fall back to searching files that were not parsed (e.g., after parse errors)
 	for _, searchPath := range paths {
 		refStruct, err := g.parser.FindStructByName(searchPath, name, g.recursive)
 		if err != nil {
@@ -225,6 +658,64 @@ func (g *Generator) findReferencedStruct(name string, paths []string) *parser.St
 	return nil
 }
 
+// resolveAlias returns the alias with the fields of the struct it refers to.
+// The alias keeps its own name, source location and doc comment (if any).
+func (g *Generator) resolveAlias(alias parser.StructInfo, known []parser.StructInfo, paths []string) (parser.StructInfo, error) {
+	seen := map[string]bool{alias.Name: true}
+	target := alias
+	for target.AliasOf != "" {
+		name := target.AliasOf
+		if seen[name] {
+			return parser.StructInfo{}, fmt.Errorf("alias %s: circular alias via %s", alias.Name, name)
+		}
+		seen[name] = true
+
+		found := findStruct(known, name)
+		if found == nil {
+			found = g.findReferencedStruct(name, paths)
+		}
+		if found == nil {
+			return parser.StructInfo{}, fmt.Errorf("alias %s: target struct %q not found", alias.Name, name)
+		}
+		target = *found
+	}
+
+	resolved := target
+	resolved.Name = alias.Name
+	resolved.GoName = alias.GoName
+	resolved.FilePath = alias.FilePath
+	resolved.Inline = alias.Inline
+	resolved.ID = alias.ID
+	if alias.Doc != "" {
+		resolved.Doc = alias.Doc
+	}
+	return resolved, nil
+}
+
+// findStruct returns the struct with the given name from a list, or nil.
+func findStruct(structs []parser.StructInfo, name string) *parser.StructInfo {
+	for i := range structs {
+		if structs[i].Name == name {
+			return &structs[i]
+		}
+	}
+	return nil
+}
+
+// hasFlattenedEmbeds reports whether any struct has embedded fields that are
+// flattened: inline/squash embeds, or any embed without a name tag if promoted
+// is set or the struct is a parameters struct.
+func hasFlattenedEmbeds(structs []parser.StructInfo, promoted bool) bool {
+	for _, s := range structs {
+		for _, field := range s.Fields {
+			if field.Squash || (promoted || s.Parameters) && field.Promoted {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // containsDot checks if a string contains a dot (external package reference).
 func containsDot(s string) bool {
 	for _, c := range s {
@@ -235,13 +726,60 @@ func containsDot(s string) bool {
 	return false
 }
 
+// checkRegistryPath returns an error if a type's schema is written to the
+// registry file (e.g., a struct named Index), as one would overwrite the other.
+func (g *Generator) checkRegistryPath(structInfo parser.StructInfo) error {
+	for _, format := range g.formats {
+		path := g.writer.SchemaPath(schema.RegistryName, "", format)
+		if g.writer.SchemaPath(structInfo.Name, structInfo.FilePath, format) == path {
+			return fmt.Errorf("struct %s: schema file %s is also the registry file", structInfo.Name, path)
+		}
+	}
+	return nil
+}
+
+// writeRegistry writes the registry schema referencing the given types in
+// every output format.
+func (g *Generator) writeRegistry(typeNames []string) error {
+	for _, format := range g.formats {
+		builder := g.builder.WithExtension(FormatExtension(format, g.extension))
+		if err := g.writer.WriteSchema(schema.RegistryName, "", builder.BuildRegistry(typeNames), format); err != nil {
+			return fmt.Errorf("write registry: %w", err)
+		}
+	}
+	return nil
+}
+
 // GenerateSingle generates a schema for a single struct.
 func (g *Generator) GenerateSingle(structInfo parser.StructInfo) error {
-	refTracker := schema.NewRefTracker()
-	jsonSchema, err := g.builder.BuildSchema(structInfo, refTracker)
-	if err != nil {
-		return fmt.Errorf("build schema: %w", err)
-	}
+	return g.generate(structInfo)
+}
 
-	return g.writer.WriteSchema(structInfo.Name, jsonSchema)
+// generate builds and writes a struct's schema in every output format.
+// Each format is built separately so its $id and $refs use that format's extension.
+// +schema:parameters structs are written as an OpenAPI parameters array instead.
+func (g *Generator) generate(structInfo parser.StructInfo) error {
+	for _, format := range g.formats {
+		builder := g.builder.WithExtension(FormatExtension(format, g.extension))
+		if structInfo.Parameters {
+			params, err := builder.BuildParameters(structInfo, builder.NewRefTracker())
+			if err != nil {
+				return fmt.Errorf("build parameters for %s: %w", structInfo.Name, err)
+			}
+			if err := g.writer.WriteParameters(structInfo.Name, structInfo.FilePath, params, format); err != nil {
+				return fmt.Errorf("write parameters for %s: %w", structInfo.Name, err)
+			}
+			continue
+		}
+
+		jsonSchema, err := builder.BuildSchema(structInfo, builder.NewRefTracker())
+		if err != nil {
+			return fmt.Errorf("build schema for %s: %w", structInfo.Name, err)
+		}
+
+		if err := g.writer.WriteSchema(structInfo.Name, structInfo.FilePath, jsonSchema, format); err != nil {
+			return fmt.Errorf("write schema for %s: %w", structInfo.Name, err)
+		}
+	}
+	return nil
 }
@@ -0,0 +1,58 @@
+package generator
+
+import (
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/ron96g/json-schema-gen/internal/parser"
+)
+
+// writeGroups writes one schema per source file, bundling the structs of
+// that file under $defs. Groups are named after their source file
+// (models.go is written to models.schema.json).
+func (g *Generator) writeGroups(groups map[string][]parser.StructInfo, errs *ErrorList) error {
+	sources := make(map[string]string) // Source file of each group name
+	for _, file := range slices.Sorted(maps.Keys(groups)) {
+		name := GroupName(file)
+		if other, ok := sources[name]; ok {
+			err := fmt.Errorf("group %s: %s and %s would be written to the same schema file", name, other, file)
+			if err := g.handleError(errs, err); err != nil {
+				return err
+			}
+			continue
+		}
+		sources[name] = file
+
+		if err := g.generateGroup(name, file, groups[file]); err != nil {
+			if err := g.handleError(errs, err); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// generateGroup builds and writes a grouped schema in every output format.
+func (g *Generator) generateGroup(name, sourceFile string, structs []parser.StructInfo) error {
+	for _, format := range g.formats {
+		builder := g.builder.WithExtension(FormatExtension(format, g.extension))
+		jsonSchema, err := builder.BuildGroup(name, sourceFile, structs)
+		if err != nil {
+			return fmt.Errorf("build group %s: %w", name, err)
+		}
+		if err := g.writer.WriteSchema(name, sourceFile, jsonSchema, format); err != nil {
+			return fmt.Errorf("write group %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// GroupName returns the name of the grouped schema of a source file: its
+// base name without extension.
+func GroupName(sourceFile string) string {
+	base := filepath.Base(sourceFile)
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
@@ -0,0 +1,94 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ron96g/json-schema-gen/internal/parser"
+	"github.com/ron96g/json-schema-gen/internal/schema"
+)
+
+// upToDate reports whether all output files of a type are newer than the
+// source files of the type and of every type it transitively depends on.
+// Missing outputs or sources that cannot be stat'ed force regeneration.
+func (g *Generator) upToDate(typeName string, structMap map[string]parser.StructInfo, depGraph *schema.DependencyGraph) bool {
+	var newest time.Time
+	for _, path := range g.sourceFiles(typeName, structMap, depGraph) {
+		modTime, ok := g.sourceModTime(path)
+		if !ok {
+			return false
+		}
+		if modTime.After(newest) {
+			newest = modTime
+		}
+	}
+
+	structInfo := structMap[typeName]
+	for _, format := range g.formats {
+		info, err := os.Stat(g.writer.SchemaPath(structInfo.Name, structInfo.FilePath, format))
+		if err != nil || info.ModTime().Before(newest) {
+			return false
+		}
+	}
+	return true
+}
+
+// sourceFiles returns the source files of the packages declaring typeName
+// and its transitive dependencies. Whole packages are returned, since a
+// schema also depends on enum constants, marshaler methods and named types
+// declared in other files. External types have no source in the scanned paths.
+func (g *Generator) sourceFiles(typeName string, structMap map[string]parser.StructInfo, depGraph *schema.DependencyGraph) []string {
+	var files []string
+	seen := make(map[string]bool)
+	seenDirs := make(map[string]bool)
+	queue := []string{typeName}
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		queue = append(queue, depGraph.GetDependencies(name)...)
+
+		structInfo, ok := structMap[name]
+		if !ok {
+			continue
+		}
+		dir := filepath.Dir(structInfo.FilePath)
+		if seenDirs[dir] {
+			continue
+		}
+		seenDirs[dir] = true
+		files = append(files, g.packageFiles(dir, structInfo.FilePath)...)
+	}
+	return files
+}
+
+// packageFiles returns the cached Go source files of a directory. If the
+// directory cannot be read, only the file declaring the type is returned.
+func (g *Generator) packageFiles(dir, filePath string) []string {
+	if files, ok := g.dirFiles[dir]; ok {
+		return files
+	}
+	files, err := g.parser.SourceFiles(dir)
+	if err != nil {
+		return []string{filePath}
+	}
+	g.dirFiles[dir] = files
+	return files
+}
+
+// sourceModTime returns the cached modification time of a source file.
+func (g *Generator) sourceModTime(path string) (time.Time, bool) {
+	if modTime, ok := g.modTimes[path]; ok {
+		return modTime, true
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}, false
+	}
+	g.modTimes[path] = info.ModTime()
+	return info.ModTime(), true
+}
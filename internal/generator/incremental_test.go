@@ -0,0 +1,133 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// copyTestdata copies a testdata directory to a temporary directory and
+// returns it, so tests may modify its files.
+func copyTestdata(t *testing.T, name string) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.CopyFS(dir, os.DirFS(filepath.Join("testdata", name))); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+// readOutput returns the content of a generated file.
+func readOutput(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+// markStale overwrites generated files, so regeneration is observable.
+func markStale(t *testing.T, paths ...string) {
+	t.Helper()
+	for _, path := range paths {
+		if err := os.WriteFile(path, []byte("stale"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// setModTime sets the modification time of files.
+func setModTime(t *testing.T, modTime time.Time, paths ...string) {
+	t.Helper()
+	for _, path := range paths {
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestIncrementalSkipsUpToDateTypes(t *testing.T) {
+	src := copyTestdata(t, "deps")
+	out := filepath.Join(t.TempDir(), "schemas")
+	user := filepath.Join(out, "user.schema.json")
+	address := filepath.Join(out, "address.schema.json")
+	note := filepath.Join(out, "note.schema.json")
+
+	generate := func() {
+		t.Helper()
+		g := NewGenerator(Config{OutputDir: out, Recursive: true, Incremental: true})
+		if err := g.GenerateFromPaths([]string{src}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	now := time.Now()
+	sources := []string{filepath.Join(src, "user.go"), filepath.Join(src, "address.go"), filepath.Join(src, "notes", "note.go")}
+	setModTime(t, now.Add(-time.Hour), sources...)
+
+	// Outputs newer than their sources are up to date
+	generate()
+	markStale(t, user, address, note)
+	setModTime(t, now, user, address, note)
+	generate()
+	for _, path := range []string{user, address, note} {
+		if readOutput(t, path) != "stale" {
+			t.Errorf("%s regenerated although up to date", path)
+		}
+	}
+
+	// A dependency newer than the output regenerates the types referencing it
+	setModTime(t, now.Add(time.Hour), filepath.Join(src, "address.go"))
+	generate()
+	for path, want := range map[string]bool{user: true, address: true, note: false} {
+		if regenerated := readOutput(t, path) != "stale"; regenerated != want {
+			t.Errorf("%s regenerated = %v, want %v", path, regenerated, want)
+		}
+	}
+
+	// Missing outputs are generated
+	if err := os.Remove(note); err != nil {
+		t.Fatal(err)
+	}
+	generate()
+	if _, err := os.Stat(note); err != nil {
+		t.Errorf("missing output not generated: %v", err)
+	}
+}
+
+// TestIncrementalFollowsPackageFiles checks that a type is regenerated when
+// another file of its package changes, such as the constants of an enum.
+func TestIncrementalFollowsPackageFiles(t *testing.T) {
+	src := copyTestdata(t, "enums")
+	out := filepath.Join(t.TempDir(), "schemas")
+	ticket := filepath.Join(out, "ticket.schema.json")
+	status := filepath.Join(src, "status.go")
+
+	generate := func() {
+		t.Helper()
+		g := NewGenerator(Config{OutputDir: out, Incremental: true})
+		if err := g.GenerateFromPaths([]string{src}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	now := time.Now()
+	setModTime(t, now.Add(-time.Hour), filepath.Join(src, "ticket.go"), status)
+	generate()
+	markStale(t, ticket)
+	setModTime(t, now, ticket)
+
+	// Adding an enum constant changes the enum of every field of that type
+	content := readOutput(t, status) + "\nconst StatusPending Status = \"pending\"\n"
+	if err := os.WriteFile(status, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	setModTime(t, now.Add(time.Hour), status)
+	generate()
+	if got := readOutput(t, ticket); !strings.Contains(got, `"pending"`) {
+		t.Errorf("ticket schema = %s, want regenerated with the pending status", got)
+	}
+}
@@ -0,0 +1,70 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// resolvePackagePaths loads Go packages by pattern (e.g., ./... or an import
+// path) through the module graph and returns their source directories.
+func resolvePackagePaths(patterns, buildTags []string) ([]string, error) {
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles,
+	}
+	if len(buildTags) > 0 {
+		cfg.BuildFlags = []string{"-tags=" + strings.Join(buildTags, ",")}
+	}
+
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return nil, fmt.Errorf("load packages: %w", err)
+	}
+
+	var dirs []string
+	seen := make(map[string]bool)
+	for _, pkg := range pkgs {
+		if len(pkg.Errors) > 0 {
+			return nil, fmt.Errorf("load package %s: %v", pkg.PkgPath, pkg.Errors[0])
+		}
+		if len(pkg.GoFiles) == 0 {
+			continue
+		}
+
+		dir := filepath.Dir(pkg.GoFiles[0])
+		if !seen[dir] {
+			seen[dir] = true
+			dirs = append(dirs, dir)
+		}
+	}
+
+	if len(dirs) == 0 {
+		return nil, fmt.Errorf("no packages matched: %v", patterns)
+	}
+	return dirs, nil
+}
+
+// expandGlobs expands path arguments containing wildcards (e.g., a quoted
+// ./models/*.go the shell did not expand). Existing paths are kept literally.
+func expandGlobs(paths []string) ([]string, error) {
+	var expanded []string
+	for _, path := range paths {
+		if _, err := os.Stat(path); err == nil || !strings.ContainsAny(path, "*?[") {
+			expanded = append(expanded, path)
+			continue
+		}
+
+		matches, err := filepath.Glob(path)
+		if err != nil {
+			return nil, fmt.Errorf("invalid glob %q: %w", path, err)
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("no files match %q", path)
+		}
+		expanded = append(expanded, matches...)
+	}
+	return expanded, nil
+}
@@ -0,0 +1,72 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/ron96g/json-schema-gen/internal/parser"
+	"github.com/ron96g/json-schema-gen/internal/schema"
+)
+
+// changedFiles returns the absolute paths of files that differ from the given
+// git ref in the repository containing dir, including uncommitted changes and
+// untracked files.
+func changedFiles(dir, ref string) (map[string]bool, error) {
+	root, err := git(dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, err
+	}
+	diff, err := git(dir, "diff", "--name-only", ref, "--")
+	if err != nil {
+		return nil, err
+	}
+	untracked, err := git(dir, "ls-files", "--others", "--exclude-standard", "--full-name", root)
+	if err != nil {
+		return nil, err
+	}
+
+	changed := make(map[string]bool)
+	for _, name := range strings.Split(diff+"\n"+untracked, "\n") {
+		if name == "" {
+			continue
+		}
+		changed[filepath.Join(root, filepath.FromSlash(name))] = true
+	}
+	return changed, nil
+}
+
+// git runs a git command in dir and returns its trimmed output.
+func git(dir string, args ...string) (string, error) {
+	var stderr bytes.Buffer
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// unchangedSince reports whether neither the source of a type nor the
+// sources of its dependencies are in the changed file set. Missing outputs
+// (e.g., in a fresh output directory) force regeneration.
+func (g *Generator) unchangedSince(changed map[string]bool, typeName string, structMap map[string]parser.StructInfo, depGraph *schema.DependencyGraph) bool {
+	structInfo := structMap[typeName]
+	for _, format := range g.formats {
+		if _, err := os.Stat(g.writer.SchemaPath(structInfo.Name, structInfo.FilePath, format)); err != nil {
+			return false
+		}
+	}
+
+	for _, path := range g.sourceFiles(typeName, structMap, depGraph) {
+		abs, err := filepath.Abs(path)
+		if err != nil || changed[abs] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,85 @@
+package generator
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestSinceGeneratesChangedTypes(t *testing.T) {
+	src := copyTestdata(t, "deps")
+	out := filepath.Join(t.TempDir(), "schemas")
+	user := filepath.Join(out, "user.schema.json")
+	address := filepath.Join(out, "address.schema.json")
+	note := filepath.Join(out, "note.schema.json")
+
+	var changed map[string]bool
+	generate := func() {
+		t.Helper()
+		g := NewGenerator(Config{OutputDir: out, Recursive: true, Since: "main"})
+		g.changedFiles = func(dir, ref string) (map[string]bool, error) {
+			if dir != src {
+				t.Errorf("dir = %q, want %q", dir, src)
+			}
+			if ref != "main" {
+				t.Errorf("ref = %q, want main", ref)
+			}
+			return changed, nil
+		}
+		if err := g.GenerateFromPaths([]string{src}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Missing outputs are generated even if nothing changed
+	changed = map[string]bool{}
+	generate()
+	for _, path := range []string{user, address, note} {
+		if readOutput(t, path) == "stale" {
+			t.Errorf("%s not generated", path)
+		}
+	}
+
+	// Nothing changed: existing outputs are kept
+	markStale(t, user, address, note)
+	generate()
+	for _, path := range []string{user, address, note} {
+		if readOutput(t, path) != "stale" {
+			t.Errorf("%s regenerated without changes", path)
+		}
+	}
+
+	// A changed dependency regenerates the types referencing it
+	changed = map[string]bool{filepath.Join(src, "address.go"): true}
+	generate()
+	for path, want := range map[string]bool{user: true, address: true, note: false} {
+		if regenerated := readOutput(t, path) != "stale"; regenerated != want {
+			t.Errorf("%s regenerated = %v, want %v", path, regenerated, want)
+		}
+	}
+}
+
+// TestSinceFollowsPackageFiles checks that a type is regenerated when another
+// file of its package changed, such as the constants of an enum.
+func TestSinceFollowsPackageFiles(t *testing.T) {
+	src := copyTestdata(t, "enums")
+	out := filepath.Join(t.TempDir(), "schemas")
+	ticket := filepath.Join(out, "ticket.schema.json")
+
+	generate := func(changed map[string]bool) {
+		t.Helper()
+		g := NewGenerator(Config{OutputDir: out, Since: "main"})
+		g.changedFiles = func(string, string) (map[string]bool, error) {
+			return changed, nil
+		}
+		if err := g.GenerateFromPaths([]string{src}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	generate(map[string]bool{})
+	markStale(t, ticket)
+	generate(map[string]bool{filepath.Join(src, "status.go"): true})
+	if readOutput(t, ticket) == "stale" {
+		t.Error("ticket schema not regenerated after its enum constants changed")
+	}
+}
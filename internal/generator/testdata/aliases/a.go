@@ -0,0 +1,4 @@
+package models
+
+// +schema
+type A = MissingA
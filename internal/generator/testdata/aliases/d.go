@@ -0,0 +1,6 @@
+package models
+
+// +schema
+type D struct {
+	Name string `json:"name"`
+}
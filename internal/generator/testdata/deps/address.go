@@ -0,0 +1,6 @@
+package models
+
+// +schema
+type Address struct {
+	City string `json:"city"`
+}
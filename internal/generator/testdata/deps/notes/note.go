@@ -0,0 +1,6 @@
+package notes
+
+// +schema
+type Note struct {
+	Text string `json:"text"`
+}
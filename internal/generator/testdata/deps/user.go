@@ -0,0 +1,6 @@
+package models
+
+// +schema
+type User struct {
+	Address Address `json:"address"`
+}
@@ -0,0 +1,6 @@
+package models
+
+// +schema
+type Ticket struct {
+	Status Status `json:"status"`
+}
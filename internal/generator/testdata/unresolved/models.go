@@ -0,0 +1,6 @@
+package models
+
+// +schema
+type Order struct {
+	Customer Customer `json:"customer"`
+}
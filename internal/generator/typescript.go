@@ -0,0 +1,108 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/ron96g/json-schema-gen/internal/parser"
+	"github.com/ron96g/json-schema-gen/internal/schema"
+)
+
+// TSExtension is the file extension of TypeScript enum files.
+const TSExtension = ".ts"
+
+// writeTSEnums writes a TypeScript file for every extracted enum used by the
+// fields of the emitted types or of the types they depend on, i.e. every enum
+// appearing in the written schemas.
+func (g *Generator) writeTSEnums(typeNames []string, structMap map[string]parser.StructInfo, depGraph *schema.DependencyGraph, errs *ErrorList) error {
+	enums := g.parser.Enums()
+	used := make(map[string]bool)
+	seen := make(map[string]bool)
+	queue := slices.Clone(typeNames)
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		queue = append(queue, depGraph.GetDependencies(name)...)
+
+		for _, field := range structMap[name].Fields {
+			addEnumTypes(used, &field.Type, enums)
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(used)) {
+		if err := g.writer.WriteTSEnum(name, enums[name]); err != nil {
+			if err := g.handleError(errs, fmt.Errorf("write TypeScript enum %s: %w", name, err)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// addEnumTypes adds the enum a type refers to, directly or as the element or
+// key type of a collection or pointer.
+func addEnumTypes(used map[string]bool, typeInfo *parser.TypeInfo, enums map[string][]parser.EnumValue) {
+	for ; typeInfo != nil; typeInfo = typeInfo.ElemType {
+		// Enums are local named types, like in the builder's applyEnum
+		if typeInfo.Kind == parser.TypeKindAlias && typeInfo.PackageName == "" && len(enums[typeInfo.Name]) > 0 {
+			used[typeInfo.Name] = true
+		}
+		if typeInfo.KeyType != nil {
+			addEnumTypes(used, typeInfo.KeyType, enums)
+		}
+	}
+}
+
+// WriteTSEnum writes the values of an enum as a TypeScript const array and
+// string-literal union type to <name>.ts in the output directory.
+func (w *Writer) WriteTSEnum(typeName string, values []parser.EnumValue) error {
+	outputDir := w.resolveOutputDir("")
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("create output directory: %w", err)
+	}
+
+	content, err := TSEnum(typeName, values)
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(outputDir, GetSchemaFilename(typeName, TSExtension))
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("write file: %w", err)
+	}
+
+	fmt.Printf("Generated: %s\n", path)
+	return nil
+}
+
+// TSEnum renders the values of an enum as TypeScript:
+//
+//	export const StatusValues = ["active", "inactive"] as const;
+//
+//	export type Status = "active" | "inactive";
+func TSEnum(typeName string, values []parser.EnumValue) (string, error) {
+	literals := make([]string, 0, len(values))
+	for _, v := range values {
+		// JSON literals of strings, numbers and booleans are valid TypeScript
+		literal, err := json.Marshal(v.Value)
+		if err != nil {
+			return "", fmt.Errorf("value %s: %w", v.Name, err)
+		}
+		literals = append(literals, string(literal))
+	}
+
+	var b strings.Builder
+	b.WriteString("// Code generated by json-schema-gen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "export const %sValues = [%s] as const;\n\n", typeName, strings.Join(literals, ", "))
+	fmt.Fprintf(&b, "export type %s = %s;\n", typeName, strings.Join(literals, " | "))
+	return b.String(), nil
+}
@@ -0,0 +1,20 @@
+package generator
+
+import "fmt"
+
+// WarningCollector prints and records warnings emitted during generation.
+type WarningCollector struct {
+	warnings []string
+}
+
+// Warnf prints a formatted warning and records it.
+func (c *WarningCollector) Warnf(format string, args ...any) {
+	msg := fmt.Sprintf(format, args...)
+	c.warnings = append(c.warnings, msg)
+	fmt.Printf("Warning: %s\n", msg)
+}
+
+// Warnings returns all recorded warnings.
+func (c *WarningCollector) Warnings() []string {
+	return c.warnings
+}
@@ -0,0 +1,28 @@
+package generator
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestFailOnWarning checks that an unresolved ref fails generation (and so
+// exits nonzero) with FailOnWarning, and only warns without it.
+func TestFailOnWarning(t *testing.T) {
+	for _, failOnWarning := range []bool{false, true} {
+		out := filepath.Join(t.TempDir(), "schemas")
+		g := NewGenerator(Config{OutputDir: out, FailOnWarning: failOnWarning})
+		err := g.GenerateFromPaths([]string{filepath.Join("testdata", "unresolved")})
+
+		warnings := g.warnings.Warnings()
+		if len(warnings) != 1 || !strings.Contains(warnings[0], `"Customer"`) {
+			t.Errorf("warnings = %q, want the unresolved Customer ref", warnings)
+		}
+		switch {
+		case failOnWarning && (err == nil || !strings.Contains(err.Error(), "1 warning(s)")):
+			t.Errorf("FailOnWarning: error = %v, want warning count", err)
+		case !failOnWarning && err != nil:
+			t.Errorf("error = %v, want nil", err)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package generator
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -8,36 +9,78 @@ import (
 	"strings"
 
 	"github.com/invopop/jsonschema"
+	"github.com/ron96g/json-schema-gen/internal/schema"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// OutputRelativeToCWD resolves a relative output directory against the working directory.
+	OutputRelativeToCWD = "cwd"
+	// OutputRelativeToFile resolves a relative output directory against each source file's directory.
+	OutputRelativeToFile = "file"
+)
+
+const (
+	// FormatJSON writes schemas as indented JSON.
+	FormatJSON = "json"
+	// FormatYAML writes schemas as YAML with the same key order as the JSON output.
+	FormatYAML = "yaml"
 )
 
 // Writer handles writing JSON Schema files to disk.
 type Writer struct {
-	outputDir string
+	outputDir  string
+	relativeTo string            // Base for relative output directories (cwd or file)
+	extension  string            // Schema file extension
+	filenames  map[string]string // Custom +schema:file names by type name
 }
 
 // NewWriter creates a new Writer.
-func NewWriter(outputDir string) *Writer {
+func NewWriter(outputDir, relativeTo, extension string) *Writer {
 	return &Writer{
-		outputDir: outputDir,
+		outputDir:  filepath.Clean(outputDir),
+		relativeTo: relativeTo,
+		extension:  extension,
 	}
 }
 
-// WriteSchema writes a JSON Schema to a file.
-func (w *Writer) WriteSchema(typeName string, schema *jsonschema.Schema) error {
+// SetFilenames configures custom schema filenames (from +schema:file) by type name.
+func (w *Writer) SetFilenames(filenames map[string]string) {
+	w.filenames = filenames
+}
+
+// WriteSchema writes a JSON Schema to a file in the given format.
+// sourceFile is the Go file the type was parsed from and is used to resolve
+// the output directory when writing relative to source files.
+func (w *Writer) WriteSchema(typeName, sourceFile string, schema *jsonschema.Schema, format string) error {
+	return w.writeDocument(typeName, sourceFile, schema, format)
+}
+
+// WriteParameters writes the OpenAPI parameters of a type to its schema file
+// in the given format.
+func (w *Writer) WriteParameters(typeName, sourceFile string, params []schema.Parameter, format string) error {
+	return w.writeDocument(typeName, sourceFile, params, format)
+}
+
+// writeDocument marshals doc to the schema file of a type.
+func (w *Writer) writeDocument(typeName, sourceFile string, doc any, format string) error {
+	filepath := w.SchemaPath(typeName, sourceFile, format)
+
 	// Ensure output directory exists
-	if err := os.MkdirAll(w.outputDir, 0755); err != nil {
+	if err := os.MkdirAll(w.resolveOutputDir(sourceFile), 0755); err != nil {
 		return fmt.Errorf("create output directory: %w", err)
 	}
 
-	// Generate filename: lowercase typename + .schema.json
-	filename := strings.ToLower(typeName) + ".schema.json"
-	filepath := filepath.Join(w.outputDir, filename)
-
 	// Marshal to JSON with indentation
-	data, err := json.MarshalIndent(schema, "", "  ")
+	data, err := json.MarshalIndent(doc, "", "  ")
 	if err != nil {
 		return fmt.Errorf("marshal schema: %w", err)
 	}
+	if format == FormatYAML {
+		if data, err = jsonToYAML(data); err != nil {
+			return fmt.Errorf("marshal schema: %w", err)
+		}
+	}
 
 	// Write to file
 	if err := os.WriteFile(filepath, data, 0644); err != nil {
@@ -48,7 +91,68 @@ func (w *Writer) WriteSchema(typeName string, schema *jsonschema.Schema) error {
 	return nil
 }
 
+// SchemaPath returns the path a type's schema is written to in the given format.
+func (w *Writer) SchemaPath(typeName, sourceFile, format string) string {
+	// Generate filename: lowercase typename + extension (.schema.json by default)
+	filename := GetSchemaFilename(typeName, FormatExtension(format, w.extension))
+	if custom := w.filenames[typeName]; custom != "" {
+		filename = schema.CustomFilename(custom, FormatExtension(format, w.extension))
+	}
+	return filepath.Join(w.resolveOutputDir(sourceFile), filename)
+}
+
+// resolveOutputDir returns the directory a schema should be written to.
+// Absolute output directories are always used as-is.
+func (w *Writer) resolveOutputDir(sourceFile string) string {
+	if w.relativeTo != OutputRelativeToFile || sourceFile == "" || filepath.IsAbs(w.outputDir) {
+		return w.outputDir
+	}
+	return filepath.Join(filepath.Dir(sourceFile), w.outputDir)
+}
+
 // GetSchemaFilename returns the schema filename for a type.
-func GetSchemaFilename(typeName string) string {
-	return strings.ToLower(typeName) + ".schema.json"
+func GetSchemaFilename(typeName, extension string) string {
+	return schema.SchemaFilename(typeName, extension)
+}
+
+// FormatExtension returns the file extension for a format. JSON uses the
+// configured extension; YAML replaces its trailing ".json" (or appends ".yaml").
+func FormatExtension(format, extension string) string {
+	if extension == "" {
+		extension = schema.DefaultExtension
+	}
+	if format == FormatYAML {
+		return strings.TrimSuffix(extension, ".json") + ".yaml"
+	}
+	return extension
+}
+
+// jsonToYAML converts JSON to block-style YAML. Decoding into a yaml.Node
+// keeps the key order of the JSON document.
+func jsonToYAML(data []byte) ([]byte, error) {
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, err
+	}
+	clearStyle(&node)
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&node); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// clearStyle resets the flow and quoting styles inherited from the JSON
+// source so the encoder picks plain YAML styles.
+func clearStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		clearStyle(child)
+	}
 }
@@ -0,0 +1,33 @@
+package parser
+
+import (
+	"bytes"
+	"go/build"
+	"io"
+	"path/filepath"
+)
+
+// newBuildContext returns the context used to evaluate build constraints:
+// the host (or $GOOS, $GOARCH and $CGO_ENABLED) settings of go/build, plus
+// the configured build tags.
+func newBuildContext(tags []string) build.Context {
+	ctx := build.Default
+	ctx.BuildTags = append(ctx.BuildTags, tags...)
+	return ctx
+}
+
+// matchesBuildContext reports whether a file would be built in the parser's
+// build context, considering both its //go:build (or // +build) constraints
+// and its _GOOS/_GOARCH file name suffixes.
+func (p *Parser) matchesBuildContext(filePath string, src []byte) bool {
+	ctx := p.buildContext
+	// Evaluate the source already read instead of reading the file again
+	ctx.OpenFile = func(string) (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(src)), nil
+	}
+
+	dir, name := filepath.Split(filePath)
+	match, err := ctx.MatchFile(dir, name)
+	// Files with malformed constraints are not skipped
+	return err != nil || match
+}
@@ -0,0 +1,100 @@
+package parser
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	// PropertyCaseOriginal keeps the Go field name (default).
+	PropertyCaseOriginal = "original"
+	// PropertyCaseCamel converts field names to camelCase (UserID -> userID).
+	PropertyCaseCamel = "camel"
+	// PropertyCaseSnake converts field names to snake_case (UserID -> user_id).
+	PropertyCaseSnake = "snake"
+	// PropertyCasePascal converts field names to PascalCase (userID -> UserID).
+	PropertyCasePascal = "pascal"
+)
+
+// applyPropertyCase converts a Go field name to the given property case.
+// Acronyms are kept together as one word ("HTTPServer" is "http_server" in snake case).
+func applyPropertyCase(name, propertyCase string) string {
+	words := splitWords(name)
+
+	switch propertyCase {
+	case PropertyCaseCamel:
+		for i, word := range words {
+			if i == 0 {
+				words[i] = strings.ToLower(word)
+			} else {
+				words[i] = upperFirst(word)
+			}
+		}
+		return strings.Join(words, "")
+
+	case PropertyCaseSnake:
+		for i, word := range words {
+			words[i] = strings.ToLower(word)
+		}
+		return strings.Join(words, "_")
+
+	case PropertyCasePascal:
+		for i, word := range words {
+			words[i] = upperFirst(word)
+		}
+		return strings.Join(words, "")
+
+	default:
+		return name
+	}
+}
+
+// splitWords splits an identifier at lower->Upper transitions, at the end of
+// an acronym ("HTTPServer" -> HTTP, Server) and at underscores.
+func splitWords(name string) []string {
+	var words []string
+	var current []rune
+	runes := []rune(name)
+
+	for i, r := range runes {
+		if r == '_' {
+			if len(current) > 0 {
+				words = append(words, string(current))
+				current = nil
+			}
+			continue
+		}
+		if i > 0 && unicode.IsUpper(r) && len(current) > 0 {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				words = append(words, string(current))
+				current = nil
+			}
+		}
+		current = append(current, r)
+	}
+	if len(current) > 0 {
+		words = append(words, string(current))
+	}
+	return words
+}
+
+// upperFirst upper-cases the first letter of a word.
+func upperFirst(word string) string {
+	runes := []rune(word)
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
+// stripPrefix removes the configured prefix from a property name. Names
+// that consist only of the prefix are kept.
+func (p *Parser) stripPrefix(name string) string {
+	if p.prefix == "" {
+		return name
+	}
+	if stripped, ok := strings.CutPrefix(name, p.prefix); ok && stripped != "" {
+		return stripped
+	}
+	return name
+}
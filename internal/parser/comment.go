@@ -0,0 +1,62 @@
+package parser
+
+import "strings"
+
+// DefaultCommentDirectives are comment prefixes of tool and linter directives,
+// which are dropped from descriptions.
+var DefaultCommentDirectives = []string{
+	"go:",          // //go:generate, //go:build, ...
+	"+build",       // Legacy build constraints
+	"nolint",       // golangci-lint
+	"lint:",        // staticcheck (//lint:ignore)
+	"revive:",      // revive
+	"#nosec",       // gosec
+	"exhaustive:",  // exhaustive
+	"+kubebuilder", // kubebuilder markers
+	"+k8s:",        // Kubernetes code generators
+}
+
+// isDirectiveLine reports whether a comment line is a tool directive rather
+// than documentation.
+func (p *Parser) isDirectiveLine(text string) bool {
+	for _, prefix := range p.commentDirectives {
+		if strings.HasPrefix(text, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// joinComment joins comment lines into a description. Lines are joined with
+// spaces by default; with preserved newlines, line breaks are kept and empty
+// lines become paragraph separators.
+func (p *Parser) joinComment(lines []string) string {
+	if !p.preserveNewlines {
+		var words []string
+		for _, line := range lines {
+			if line != "" {
+				words = append(words, line)
+			}
+		}
+		return strings.Join(words, " ")
+	}
+
+	var b strings.Builder
+	paragraph := false // An empty line follows the text written so far
+	for _, line := range lines {
+		if line == "" {
+			paragraph = b.Len() > 0
+			continue
+		}
+		if b.Len() > 0 {
+			if paragraph {
+				b.WriteString("\n\n")
+			} else {
+				b.WriteString("\n")
+			}
+		}
+		paragraph = false
+		b.WriteString(line)
+	}
+	return b.String()
+}
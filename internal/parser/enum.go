@@ -0,0 +1,176 @@
+package parser
+
+import (
+	"go/ast"
+	"go/token"
+	"strconv"
+)
+
+// EnumValue is a typed constant of a named type (e.g., StatusActive Status = "active").
+type EnumValue struct {
+	Name  string // Constant name
+	Value any    // Constant value (string, int64, float64 or bool)
+	Doc   string // Trailing line comment, or the comment above the constant
+}
+
+// extractEnums records typed constants in the file as enum values of their type.
+// Constants without a value repeat the type and expression of the previous
+// spec in their block, with iota set to their index (Red Color = iota; Green; Blue).
+func (p *Parser) extractEnums(file *ast.File) {
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.CONST {
+			continue
+		}
+
+		var prevType ast.Expr
+		var prevValues []ast.Expr
+		for iota, spec := range genDecl.Specs {
+			valueSpec, ok := spec.(*ast.ValueSpec)
+			if !ok {
+				continue
+			}
+
+			// Implicit repetition of the previous expression list
+			typeExpr, values := valueSpec.Type, valueSpec.Values
+			if len(values) == 0 && typeExpr == nil {
+				typeExpr, values = prevType, prevValues
+			}
+			prevType, prevValues = typeExpr, values
+			if len(valueSpec.Names) != len(values) {
+				continue
+			}
+
+			typeIdent, ok := typeExpr.(*ast.Ident)
+			if !ok {
+				continue // Untyped or qualified constants
+			}
+
+			doc := p.extractCommentText(valueSpec.Comment)
+			if doc == "" {
+				doc = p.extractCommentText(valueSpec.Doc)
+			}
+
+			for i, name := range valueSpec.Names {
+				if name.Name == "_" {
+					continue
+				}
+				value, ok := constValue(values[i], int64(iota))
+				if !ok {
+					continue
+				}
+				p.addEnumValue(typeIdent.Name, EnumValue{
+					Name:  name.Name,
+					Value: value,
+					Doc:   doc,
+				})
+			}
+		}
+	}
+}
+
+// addEnumValue records an enum value unless the constant is already known
+// (files may be parsed more than once while resolving references).
+func (p *Parser) addEnumValue(typeName string, value EnumValue) {
+	for _, existing := range p.enums[typeName] {
+		if existing.Name == value.Name {
+			return
+		}
+	}
+	p.enums[typeName] = append(p.enums[typeName], value)
+}
+
+// constValue evaluates a literal constant expression. Integer expressions
+// may use iota and basic arithmetic (iota + 1, 1 << iota).
+func constValue(expr ast.Expr, iota int64) (any, bool) {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		switch e.Kind {
+		case token.STRING:
+			s, err := strconv.Unquote(e.Value)
+			return s, err == nil
+		case token.INT:
+			n, err := strconv.ParseInt(e.Value, 0, 64)
+			return n, err == nil
+		case token.FLOAT:
+			f, err := strconv.ParseFloat(e.Value, 64)
+			return f, err == nil
+		}
+
+	case *ast.Ident:
+		switch e.Name {
+		case "true":
+			return true, true
+		case "false":
+			return false, true
+		case "iota":
+			return iota, true
+		}
+
+	case *ast.BinaryExpr:
+		x, okX := constValue(e.X, iota)
+		y, okY := constValue(e.Y, iota)
+		a, isIntX := x.(int64)
+		b, isIntY := y.(int64)
+		if !okX || !okY || !isIntX || !isIntY {
+			break
+		}
+		return intBinaryOp(e.Op, a, b)
+
+	case *ast.UnaryExpr:
+		if e.Op != token.SUB {
+			break
+		}
+		value, ok := constValue(e.X, iota)
+		switch n := value.(type) {
+		case int64:
+			return -n, ok
+		case float64:
+			return -n, ok
+		}
+
+	case *ast.ParenExpr:
+		return constValue(e.X, iota)
+	}
+	return nil, false
+}
+
+// intBinaryOp applies an integer operator of a constant expression.
+func intBinaryOp(op token.Token, a, b int64) (any, bool) {
+	switch op {
+	case token.ADD:
+		return a + b, true
+	case token.SUB:
+		return a - b, true
+	case token.MUL:
+		return a * b, true
+	case token.QUO:
+		if b != 0 {
+			return a / b, true
+		}
+	case token.REM:
+		if b != 0 {
+			return a % b, true
+		}
+	case token.SHL:
+		if b >= 0 && b < 64 {
+			return a << b, true
+		}
+	case token.SHR:
+		if b >= 0 && b < 64 {
+			return a >> b, true
+		}
+	case token.OR:
+		return a | b, true
+	case token.AND:
+		return a & b, true
+	case token.XOR:
+		return a ^ b, true
+	}
+	return nil, false
+}
+
+// Enums returns the typed constants found so far, keyed by type name.
+func (p *Parser) Enums() map[string][]EnumValue {
+	return p.enums
+}
@@ -3,11 +3,12 @@ package parser
 import (
 	"go/ast"
 	"reflect"
+	"slices"
 	"strings"
 )
 
 var (
-	commonTags = []string{"json", "yaml", "xml", "mapstructure", "validate", "description", "schema"}
+	commonTags = []string{"json", "yaml", "xml", "mapstructure", "form", "query", "validate", "binding", "description", "schema", "in"}
 )
 
 // parseField extracts FieldInfo from an AST field.
@@ -15,21 +16,43 @@ func (p *Parser) parseField(field *ast.Field, nameTag string) []FieldInfo {
 	var fields []FieldInfo
 
 	// Get field documentation
-	doc := extractDoc(field.Doc, field.Comment)
+	doc := p.extractDoc(field.Doc, field.Comment)
 
 	// Parse struct tags
 	tags := parseTags(field.Tag)
 
+	// A +schema:type=T comment overrides the type unless the schema tag does
+	marker := fieldMarker(field.Doc, field.Comment)
+	if marker.Type != "" {
+		if schemaTag, ok := tags["schema"]; ok {
+			tags["schema"] = "type=" + marker.Type + "," + schemaTag
+		} else {
+			tags["schema"] = "type=" + marker.Type
+		}
+	}
+
+	// Types listed by +schema:anyof are declared in the same package
+	var anyOf []string
+	for _, name := range marker.AnyOf {
+		anyOf = append(anyOf, p.typeKey(p.pkg, name))
+	}
+
 	// Get property name from specified tag
 	propertyName, omitEmpty := extractPropertyName(tags, nameTag)
+	propertyName = p.stripPrefix(propertyName)
 
 	// Parse the type
 	typeInfo := p.parseTypeExpr(field.Type)
 
 	// Handle embedded fields (no names)
 	if len(field.Names) == 0 {
+		// The field is named after the type, without a package qualifier
+		embeddedName := typeInfo.Name
+		if p.qualify {
+			embeddedName = strings.Replace(embeddedName, p.pkg+"_", "", 1)
+		}
 		fieldInfo := FieldInfo{
-			Name:       typeInfo.Name,
+			Name:       embeddedName,
 			Type:       typeInfo,
 			Tags:       tags,
 			Doc:        doc,
@@ -39,12 +62,30 @@ func (p *Parser) parseField(field *ast.Field, nameTag string) []FieldInfo {
 		if propertyName != "" {
 			fieldInfo.PropertyName = propertyName
 		} else {
-			fieldInfo.PropertyName = typeInfo.Name
+			fieldInfo.PropertyName = p.stripPrefix(applyPropertyCase(embeddedName, p.propertyCase))
+			fieldInfo.Promoted = true
+		}
+		// json/yaml ",inline" and mapstructure ",squash" always flatten
+		if hasTagOption(tags[nameTag], "inline") || hasTagOption(tags[nameTag], "squash") || hasTagOption(tags["mapstructure"], "squash") {
+			fieldInfo.PropertyName = applyPropertyCase(embeddedName, p.propertyCase)
+			fieldInfo.Promoted = true
+			fieldInfo.Squash = true
 		}
 		fields = append(fields, fieldInfo)
 		return fields
 	}
 
+	// A tag name shared by several fields (X, Y int `json:"x"`) would produce
+	// duplicate properties, so each field falls back to its own name
+	if len(field.Names) > 1 && propertyName != "" && propertyName != "-" {
+		names := make([]string, len(field.Names))
+		for i, name := range field.Names {
+			names[i] = name.Name
+		}
+		p.warnf("fields %s share the %s name %q; using field names", strings.Join(names, ", "), nameTag, propertyName)
+		propertyName = ""
+	}
+
 	// Handle named fields
 	for _, name := range field.Names {
 		// Skip unexported fields
@@ -58,13 +99,14 @@ func (p *Parser) parseField(field *ast.Field, nameTag string) []FieldInfo {
 			Tags:      tags,
 			Doc:       doc,
 			OmitEmpty: omitEmpty,
+			AnyOf:     anyOf,
 		}
 
-		// Use tag name or fall back to field name
+		// Use tag name or fall back to field name in the configured case
 		if propertyName != "" {
 			fieldInfo.PropertyName = propertyName
 		} else {
-			fieldInfo.PropertyName = name.Name
+			fieldInfo.PropertyName = p.stripPrefix(applyPropertyCase(name.Name, p.propertyCase))
 		}
 
 		fields = append(fields, fieldInfo)
@@ -107,6 +149,7 @@ func extractPropertyName(tags map[string]string, nameTag string) (string, bool)
 	}
 
 	// Handle json tag format: "name,omitempty"
+	// Other options such as gin's form/query "default=value" are ignored
 	parts := strings.Split(tagValue, ",")
 	name := parts[0]
 
@@ -127,8 +170,29 @@ func extractPropertyName(tags map[string]string, nameTag string) (string, bool)
 	return name, omitEmpty
 }
 
-// extractDoc extracts documentation from AST comments.
-func extractDoc(doc *ast.CommentGroup, comment *ast.CommentGroup) string {
+// fieldMarker returns the options of +schema markers in the comments above
+// and beside a field, preferring the comment above.
+func fieldMarker(doc, comment *ast.CommentGroup) markerOptions {
+	_, opts := parseSchemaMarker(doc)
+	_, lineOpts := parseSchemaMarker(comment)
+	if opts.Type == "" {
+		opts.Type = lineOpts.Type
+	}
+	if len(opts.AnyOf) == 0 {
+		opts.AnyOf = lineOpts.AnyOf
+	}
+	return opts
+}
+
+// hasTagOption reports whether a tag value has the given option after its
+// name (e.g., inline in `json:",inline"`).
+func hasTagOption(tagValue, option string) bool {
+	parts := strings.Split(tagValue, ",")
+	return slices.Contains(parts[1:], option)
+}
+
+// extractDoc extracts documentation from AST comments, skipping tool directives.
+func (p *Parser) extractDoc(doc *ast.CommentGroup, comment *ast.CommentGroup) string {
 	var comments []string
 
 	// Prefer doc comments (above the field)
@@ -138,22 +202,23 @@ func extractDoc(doc *ast.CommentGroup, comment *ast.CommentGroup) string {
 			text = strings.TrimPrefix(text, "/*")
 			text = strings.TrimSuffix(text, "*/")
 			text = strings.TrimSpace(text)
-			if text != "" {
+			if !p.isDirectiveLine(text) && !isMarkerLine(text) {
 				comments = append(comments, text)
 			}
 		}
 	}
 
 	// Also check line comments (beside the field)
-	if len(comments) == 0 && comment != nil {
+	if p.joinComment(comments) == "" && comment != nil {
+		comments = nil
 		for _, c := range comment.List {
 			text := strings.TrimPrefix(c.Text, "//")
 			text = strings.TrimSpace(text)
-			if text != "" {
+			if !p.isDirectiveLine(text) && !isMarkerLine(text) {
 				comments = append(comments, text)
 			}
 		}
 	}
 
-	return strings.Join(comments, " ")
+	return p.joinComment(comments)
 }
@@ -0,0 +1,88 @@
+package parser
+
+import (
+	"go/ast"
+	"go/token"
+)
+
+// indexedType locates an exported struct, struct alias or named collection
+// declaration seen while parsing, so referenced types resolve without
+// re-scanning the tree.
+type indexedType struct {
+	spec        *ast.TypeSpec
+	groupDoc    *ast.CommentGroup
+	packageName string
+	filePath    string
+}
+
+// indexTypes records the exported struct, struct alias and named collection
+// declarations of a file. The first declaration of a name wins, matching the
+// search order of FindStructByName.
+func (p *Parser) indexTypes(file *ast.File, filePath string) {
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			typeSpec, ok := spec.(*ast.TypeSpec)
+			if !ok || !typeSpec.Name.IsExported() || !isIndexedSpec(typeSpec) {
+				continue
+			}
+			key := p.typeKey(file.Name.Name, typeSpec.Name.Name)
+			if _, exists := p.typeIndex[key]; exists {
+				continue
+			}
+			p.typeIndex[key] = indexedType{
+				spec:        typeSpec,
+				groupDoc:    genDecl.Doc,
+				packageName: file.Name.Name,
+				filePath:    filePath,
+			}
+		}
+	}
+}
+
+// isIndexedSpec reports whether a type spec declares a struct, an alias
+// that may refer to one (type A = B) or a named slice, array or map.
+func isIndexedSpec(typeSpec *ast.TypeSpec) bool {
+	switch t := typeSpec.Type.(type) {
+	case *ast.StructType:
+		return true
+	case *ast.ArrayType, *ast.MapType:
+		return !typeSpec.Assign.IsValid()
+	case *ast.Ident:
+		return typeSpec.Assign.IsValid() && t.IsExported()
+	}
+	return false
+}
+
+// LookupStruct returns an exported struct (or struct alias) declared in any
+// file parsed so far, without requiring the +schema annotation, or nil if
+// none was seen. Structs are built on lookup, so they see the complete type registry.
+func (p *Parser) LookupStruct(name string) *StructInfo {
+	indexed, ok := p.typeIndex[name]
+	if !ok {
+		return nil
+	}
+	return p.structFromSpec(indexed.spec, indexed.packageName, indexed.filePath, indexed.groupDoc)
+}
+
+// structFromSpec parses a struct, struct alias or named collection declaration
+// without requiring the +schema annotation, or returns nil for other types.
+func (p *Parser) structFromSpec(typeSpec *ast.TypeSpec, packageName, filePath string, groupDoc *ast.CommentGroup) *StructInfo {
+	// Parse aliases to other structs so the generator can resolve them
+	if aliasInfo, ok := p.parseStructAlias(typeSpec, packageName, filePath, groupDoc); ok {
+		return &aliasInfo
+	}
+	if collectionInfo, ok := p.parseCollection(typeSpec, packageName, filePath, groupDoc); ok {
+		return &collectionInfo
+	}
+
+	structType, ok := typeSpec.Type.(*ast.StructType)
+	if !ok {
+		return nil
+	}
+	structInfo := p.parseStruct(typeSpec, structType, packageName, filePath, groupDoc)
+	return &structInfo
+}
@@ -0,0 +1,86 @@
+package parser
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+// TestLookupStructMatchesFindStructByName checks that index lookups return
+// the same structs as searching the files.
+func TestLookupStructMatchesFindStructByName(t *testing.T) {
+	dir := filepath.Join("testdata", "index")
+
+	indexed := NewParser(Config{})
+	if _, err := indexed.ParsePath(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	names := slices.Sorted(maps.Keys(indexed.typeIndex))
+	if want := []string{"Address", "Customer", "Point", "Tags", "User"}; !slices.Equal(names, want) {
+		t.Fatalf("indexed types = %v, want %v", names, want)
+	}
+
+	for _, name := range append(names, "hidden", "Missing") {
+		t.Run(name, func(t *testing.T) {
+			searched, err := NewParser(Config{}).FindStructByName(dir, name, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := indexed.LookupStruct(name); !reflect.DeepEqual(got, searched) {
+				t.Errorf("LookupStruct(%q) = %+v, FindStructByName = %+v", name, got, searched)
+			}
+		})
+	}
+}
+
+// benchmarkFiles returns a directory of n files declaring 10 structs each.
+func benchmarkFiles(b *testing.B, n int) string {
+	dir := b.TempDir()
+	for i := range n {
+		content := "package models\n"
+		for j := range 10 {
+			content += fmt.Sprintf("\ntype Type%d_%d struct {\n\tName string `json:\"name\"`\n}\n", i, j)
+		}
+		path := filepath.Join(dir, fmt.Sprintf("file%d.go", i))
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			b.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func BenchmarkLookupStruct(b *testing.B) {
+	dir := benchmarkFiles(b, 50)
+	p := NewParser(Config{})
+	if _, err := p.ParsePath(dir); err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for i := range b.N {
+		if p.LookupStruct(fmt.Sprintf("Type%d_9", i%50)) == nil {
+			b.Fatal("struct not found")
+		}
+	}
+}
+
+func BenchmarkFindStructByName(b *testing.B) {
+	dir := benchmarkFiles(b, 50)
+	p := NewParser(Config{})
+	if _, err := p.ParsePath(dir); err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for i := range b.N {
+		found, err := p.FindStructByName(dir, fmt.Sprintf("Type%d_9", i%50), false)
+		if err != nil || found == nil {
+			b.Fatalf("struct not found: %v", err)
+		}
+	}
+}
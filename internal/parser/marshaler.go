@@ -0,0 +1,87 @@
+package parser
+
+import (
+	"go/ast"
+)
+
+// MarshalerKind describes custom serialization implemented by a type.
+type MarshalerKind int
+
+const (
+	MarshalerNone MarshalerKind = iota
+	MarshalerJSON               // Implements json.Marshaler (MarshalJSON() ([]byte, error))
+	MarshalerText               // Implements encoding.TextMarshaler (MarshalText() ([]byte, error))
+)
+
+// extractMarshalers records types in the file that implement custom marshaling methods.
+func (p *Parser) extractMarshalers(file *ast.File) {
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Recv == nil || len(funcDecl.Recv.List) != 1 {
+			continue
+		}
+
+		var kind MarshalerKind
+		switch funcDecl.Name.Name {
+		case "MarshalJSON":
+			kind = MarshalerJSON
+		case "MarshalText":
+			kind = MarshalerText
+		default:
+			continue
+		}
+
+		if !isMarshalSignature(funcDecl.Type) {
+			continue
+		}
+
+		typeName := receiverTypeName(funcDecl.Recv.List[0].Type)
+		if typeName == "" {
+			continue
+		}
+		// Struct refs use qualified names, other named types plain ones
+		for _, name := range []string{typeName, p.typeKey(file.Name.Name, typeName)} {
+			// encoding/json prefers MarshalJSON over MarshalText
+			if p.marshalers[name] != MarshalerJSON {
+				p.marshalers[name] = kind
+			}
+		}
+	}
+}
+
+// Marshalers returns the types found so far that implement custom marshaling.
+func (p *Parser) Marshalers() map[string]MarshalerKind {
+	return p.marshalers
+}
+
+// isMarshalSignature checks for the func() ([]byte, error) signature.
+func isMarshalSignature(funcType *ast.FuncType) bool {
+	if funcType.Params != nil && len(funcType.Params.List) > 0 {
+		return false
+	}
+	if funcType.Results == nil || len(funcType.Results.List) != 2 {
+		return false
+	}
+
+	bytesType, ok := funcType.Results.List[0].Type.(*ast.ArrayType)
+	if !ok || bytesType.Len != nil {
+		return false
+	}
+	if elem, ok := bytesType.Elt.(*ast.Ident); !ok || elem.Name != "byte" {
+		return false
+	}
+
+	errType, ok := funcType.Results.List[1].Type.(*ast.Ident)
+	return ok && errType.Name == "error"
+}
+
+// receiverTypeName returns the type name of a method receiver (T or *T).
+func receiverTypeName(expr ast.Expr) string {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
@@ -3,11 +3,14 @@ package parser
 import (
 	"fmt"
 	"go/ast"
+	"go/build"
 	"go/parser"
 	"go/token"
 	"io/fs"
 	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
 	"strings"
 )
 
@@ -16,25 +19,112 @@ const SchemaMarker = "+schema"
 
 // Parser handles AST parsing of Go source files.
 type Parser struct {
-	fset         *token.FileSet
-	nameTag      string               // Tag to use for property names (json, yaml, etc.)
-	typeRegistry map[string]TypeDecl  // Registry of type declarations in current package
-	parsedFiles  map[string]*ast.File // Cache of parsed AST files
+	fset              *token.FileSet
+	nameTag           string                   // Tag to use for property names (json, yaml, etc.)
+	typeRegistry      map[string]TypeDecl      // Registry of type declarations in current package
+	parsedFiles       map[string]*ast.File     // Cache of parsed AST files
+	onParse           func(filePath string)    // Called whenever a file is read and parsed (test hook)
+	typeIndex         map[string]indexedType   // Exported struct declarations by name, for ref resolution
+	buildContext      build.Context            // Build context (tags, GOOS, GOARCH) for evaluating constraints
+	marshalers        map[string]MarshalerKind // Types implementing custom marshaling
+	enums             map[string][]EnumValue   // Typed constants by type name
+	crossModule       bool                     // Descend into nested modules when scanning recursively
+	propertyCase      string                   // Case of property names for untagged fields
+	prefix            string                   // Prefix stripped from property names
+	commentDirectives []string                 // Comment prefixes of directives dropped from descriptions
+	preserveNewlines  bool                     // Keep line breaks and paragraphs in descriptions
+	includeTests      bool                     // Parse _test.go files in directories
+	includeTestdata   bool                     // Scan testdata directories recursively
+	includeVendor     bool                     // Scan vendor directories recursively
+	knownTypes        map[string]knownType     // Built-in and configured external type mappings
+	qualify           bool                     // Prefix struct names with their package (pkg_Config)
+	pkg               string                   // Package of the declaration being parsed, qualifying local refs
+	excludeFields     *regexp.Regexp           // Fields dropped from structs, matched by property or Go name
+	warnf             func(format string, args ...any)
+}
+
+// Config holds parser configuration.
+type Config struct {
+	NameTag   string   // Tag to use for property names (json, yaml, etc.)
+	BuildTags []string // Build tags for evaluating //go:build constraints
+
+	// TypeMappings maps qualified external types (e.g., null.String) to their representation
+	TypeMappings map[string]TypeMapping
+
+	CrossModule bool // Descend into nested modules (directories with a go.mod) when scanning recursively
+
+	// PropertyCase converts untagged field names (camel, snake, pascal or original)
+	PropertyCase string
+
+	// StripPrefix is removed from the start of all property names (e.g., "x_")
+	StripPrefix string
+
+	// CommentDirectives are comment prefixes dropped from descriptions in
+	// addition to DefaultCommentDirectives (e.g., "custom:")
+	CommentDirectives []string
+
+	PreserveNewlines bool // Keep line breaks and paragraphs of comments in descriptions
+	IncludeTests     bool // Also parse _test.go files in directories
+	IncludeTestdata  bool // Descend into testdata directories when scanning recursively
+	IncludeVendor    bool // Descend into vendor directories when scanning recursively
+
+	// QualifyNames prefixes struct names with their package (pkg_Config), so
+	// same-named types of different packages get distinct schema files and refs
+	QualifyNames bool
+
+	// ExcludeFields drops struct fields whose property or Go name fully
+	// matches (e.g., Internal.*), like a json:"-" tag
+	ExcludeFields *regexp.Regexp
 }
 
 // NewParser creates a new Parser instance.
-func NewParser(nameTag string) *Parser {
+func NewParser(cfg Config) *Parser {
+	nameTag := cfg.NameTag
 	if nameTag == "" {
 		nameTag = "json"
 	}
+
+	types := make(map[string]knownType, len(knownTypes)+len(cfg.TypeMappings))
+	for name, known := range knownTypes {
+		types[name] = known
+	}
+	for name, mapping := range cfg.TypeMappings {
+		if known, ok := mapping.resolve(); ok {
+			types[name] = known
+		}
+	}
+
 	return &Parser{
-		fset:         token.NewFileSet(),
-		nameTag:      nameTag,
-		typeRegistry: make(map[string]TypeDecl),
-		parsedFiles:  make(map[string]*ast.File),
+		fset:              token.NewFileSet(),
+		nameTag:           nameTag,
+		typeRegistry:      make(map[string]TypeDecl),
+		parsedFiles:       make(map[string]*ast.File),
+		typeIndex:         make(map[string]indexedType),
+		buildContext:      newBuildContext(cfg.BuildTags),
+		marshalers:        make(map[string]MarshalerKind),
+		enums:             make(map[string][]EnumValue),
+		crossModule:       cfg.CrossModule,
+		propertyCase:      cfg.PropertyCase,
+		prefix:            cfg.StripPrefix,
+		commentDirectives: append(slices.Clone(DefaultCommentDirectives), cfg.CommentDirectives...),
+		preserveNewlines:  cfg.PreserveNewlines,
+		includeTests:      cfg.IncludeTests,
+		includeTestdata:   cfg.IncludeTestdata,
+		includeVendor:     cfg.IncludeVendor,
+		knownTypes:        types,
+		qualify:           cfg.QualifyNames,
+		excludeFields:     cfg.ExcludeFields,
+		warnf: func(format string, args ...any) {
+			fmt.Printf("Warning: "+format+"\n", args...)
+		},
 	}
 }
 
+// SetWarnFunc configures the function used to report non-fatal parse warnings.
+func (p *Parser) SetWarnFunc(warnf func(format string, args ...any)) {
+	p.warnf = warnf
+}
+
 // ParsePath parses Go files from a path (file or directory).
 func (p *Parser) ParsePath(path string) ([]StructInfo, error) {
 	return p.ParsePathWithOptions(path, false)
@@ -71,14 +161,14 @@ func (p *Parser) parseRecursive(root string) ([]StructInfo, error) {
 			return nil
 		}
 
-		if shouldSkipDir(d.Name()) {
+		if p.skipWalkDir(root, path, d.Name()) {
 			return filepath.SkipDir
 		}
 
 		structs, err := p.parseDirectory(path)
 		if err != nil {
 			// Log warning but continue with other directories
-			fmt.Printf("Warning: failed to parse %s: %v\n", path, err)
+			p.warnf("failed to parse %s: %v", path, err)
 			return nil
 		}
 		allStructs = append(allStructs, structs...)
@@ -92,6 +182,25 @@ func (p *Parser) parseRecursive(root string) ([]StructInfo, error) {
 	return allStructs, nil
 }
 
+// skipWalkDir reports whether a recursive walk from root should skip the
+// directory at path. Nested modules are separate units and only scanned
+// with cross-module scanning enabled. testdata and vendor directories are
+// skipped unless explicitly included.
+func (p *Parser) skipWalkDir(root, path, name string) bool {
+	switch {
+	case name == "testdata" && p.includeTestdata, name == "vendor" && p.includeVendor:
+	case shouldSkipDir(name):
+		return true
+	}
+	return path != root && !p.crossModule && isModuleRoot(path)
+}
+
+// isModuleRoot reports whether a directory contains a go.mod file.
+func isModuleRoot(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, "go.mod"))
+	return err == nil
+}
+
 // shouldSkipDir returns true for directories that should be skipped during recursive scanning.
 func shouldSkipDir(name string) bool {
 	skipDirs := map[string]bool{
@@ -105,29 +214,38 @@ func shouldSkipDir(name string) bool {
 	return skipDirs[name]
 }
 
+// isSourceFile reports whether a directory entry is a Go file to parse.
+// Test files are skipped unless tests are included.
+func (p *Parser) isSourceFile(name string) bool {
+	if !strings.HasSuffix(name, ".go") {
+		return false
+	}
+	return p.includeTests || !strings.HasSuffix(name, "_test.go")
+}
+
 // parseDirectory parses all Go files in a directory.
 func (p *Parser) parseDirectory(dir string) ([]StructInfo, error) {
 	var allStructs []StructInfo
 
-	entries, err := os.ReadDir(dir)
+	filePaths, err := p.SourceFiles(dir)
 	if err != nil {
-		return nil, fmt.Errorf("read directory %s: %w", dir, err)
+		return nil, err
 	}
 
-	for _, entry := range entries {
-		if entry.IsDir() {
-			continue
-		}
-		if !strings.HasSuffix(entry.Name(), ".go") {
-			continue
+	// Pass 1 for the whole package, so types, enums and their constants
+	// declared in other files are known when structs are extracted
+	files := make([]*ast.File, len(filePaths))
+	for i, filePath := range filePaths {
+		if files[i], err = p.loadFile(filePath); err != nil {
+			return nil, err
 		}
-		// Skip test files
-		if strings.HasSuffix(entry.Name(), "_test.go") {
+	}
+
+	for i, file := range files {
+		if file == nil {
 			continue
 		}
-
-		filePath := filepath.Join(dir, entry.Name())
-		structs, err := p.parseFile(filePath)
+		structs, err := p.extractStructs(file, filePaths[i])
 		if err != nil {
 			return nil, err
 		}
@@ -137,8 +255,48 @@ func (p *Parser) parseDirectory(dir string) ([]StructInfo, error) {
 	return allStructs, nil
 }
 
+// SourceFiles returns the paths of the Go source files in a directory,
+// including _test.go files only if IncludeTests is set.
+func (p *Parser) SourceFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read directory %s: %w", dir, err)
+	}
+
+	var filePaths []string
+	for _, entry := range entries {
+		if entry.IsDir() || !p.isSourceFile(entry.Name()) {
+			continue
+		}
+		filePaths = append(filePaths, filepath.Join(dir, entry.Name()))
+	}
+	return filePaths, nil
+}
+
 // parseFile parses a single Go file.
 func (p *Parser) parseFile(filePath string) ([]StructInfo, error) {
+	file, err := p.loadFile(filePath)
+	if err != nil || file == nil {
+		return nil, err
+	}
+
+	// Pass 2: Extract structs using the registry
+	return p.extractStructs(file, filePath)
+}
+
+// loadFile returns the AST of a Go file, parsing it only on first use.
+// Type declarations of a newly parsed file are added to the type registry
+// and index (pass 1), so each file contributes to them exactly once.
+// Returns nil for files excluded by build constraints.
+func (p *Parser) loadFile(filePath string) (*ast.File, error) {
+	filePath = filepath.Clean(filePath)
+	if file, ok := p.parsedFiles[filePath]; ok {
+		return file, nil
+	}
+
+	if p.onParse != nil {
+		p.onParse(filePath)
+	}
 	src, err := os.ReadFile(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("read file %s: %w", filePath, err)
@@ -149,16 +307,26 @@ func (p *Parser) parseFile(filePath string) ([]StructInfo, error) {
 		return nil, fmt.Errorf("parse file %s: %w", filePath, err)
 	}
 
+	// Skip files excluded by build constraints
+	if !p.matchesBuildContext(filePath, src) {
+		p.parsedFiles[filePath] = nil
+		return nil, nil
+	}
+
 	// Pass 1: Extract type declarations to build registry
 	p.extractTypeDecls(file)
+	p.indexTypes(file, filePath)
 
-	// Pass 2: Extract structs using the registry
-	return p.extractStructs(file, filePath)
+	p.parsedFiles[filePath] = file
+	return file, nil
 }
 
 // extractTypeDecls extracts type declarations from an AST file to build the type registry.
 // This is the first pass of parsing that identifies type aliases like `type MyEnum string`.
 func (p *Parser) extractTypeDecls(file *ast.File) {
+	p.extractMarshalers(file)
+	p.extractEnums(file)
+
 	for _, decl := range file.Decls {
 		genDecl, ok := decl.(*ast.GenDecl)
 		if !ok {
@@ -179,10 +347,19 @@ func (p *Parser) extractTypeDecls(file *ast.File) {
 				continue
 			}
 
+			// Named interfaces hold any value
+			if _, ok := typeSpec.Type.(*ast.InterfaceType); ok {
+				p.typeRegistry[typeSpec.Name.Name] = TypeDecl{
+					Name:           typeSpec.Name.Name,
+					UnderlyingKind: TypeKindInterface,
+				}
+				continue
+			}
+
 			// Check if this is a simple type alias (not a struct)
 			ident, ok := typeSpec.Type.(*ast.Ident)
 			if !ok {
-				continue // Skip structs, interfaces, etc.
+				continue // Skip structs, etc.
 			}
 
 			// Determine the underlying type kind
@@ -243,24 +420,37 @@ func (p *Parser) extractStructs(file *ast.File, filePath string) ([]StructInfo,
 				continue
 			}
 
-			structType, ok := typeSpec.Type.(*ast.StructType)
-			if !ok {
-				continue
-			}
-
 			// Require +schema annotation
-			if !hasSchemaMarker(genDecl.Doc, typeSpec.Doc) {
+			hasMarker, marker := structMarker(genDecl.Doc, typeSpec.Doc, len(genDecl.Specs) == 1)
+			if !hasMarker {
 				continue
 			}
 
-			// Extract inline preference from marker
-			_, inline := parseSchemaMarker(typeSpec.Doc)
-			if !inline {
-				_, inline = parseSchemaMarker(genDecl.Doc)
+			var structInfo StructInfo
+			if structType, ok := typeSpec.Type.(*ast.StructType); ok {
+				structInfo = p.parseStruct(typeSpec, structType, packageName, filePath, genDecl.Doc)
+				if name := marker.AdditionalProperties; name != "" {
+					structInfo.Fields, structInfo.AdditionalProperties = p.catchAllField(structType, structInfo.Fields, name)
+					if structInfo.AdditionalProperties == nil {
+						p.warnf("struct %s: additional-properties field %q not found", structInfo.Name, name)
+					}
+				}
+				if len(marker.Dependent) > 0 {
+					structInfo.DependentRequired = p.dependentRequired(structInfo, marker.Dependent)
+				}
+			} else if aliasInfo, ok := p.parseStructAlias(typeSpec, packageName, filePath, genDecl.Doc); ok {
+				structInfo = aliasInfo
+			} else if collectionInfo, ok := p.parseCollection(typeSpec, packageName, filePath, genDecl.Doc); ok {
+				structInfo = collectionInfo
+			} else {
+				continue
 			}
 
-			structInfo := p.parseStruct(typeSpec, structType, packageName, filePath, genDecl.Doc)
-			structInfo.Inline = inline
+			// Apply marker options
+			structInfo.Inline = marker.Inline
+			structInfo.Parameters = marker.Parameters
+			structInfo.ID = marker.ID
+			structInfo.File = marker.File
 			structs = append(structs, structInfo)
 		}
 	}
@@ -268,58 +458,129 @@ func (p *Parser) extractStructs(file *ast.File, filePath string) ([]StructInfo,
 	return structs, nil
 }
 
-// hasSchemaMarker checks if the doc comments contain the +schema marker.
-func hasSchemaMarker(groupDoc, typeDoc *ast.CommentGroup) bool {
-	hasMarker, _ := parseSchemaMarker(typeDoc)
-	if hasMarker {
-		return true
+// markerOptions holds the options of +schema:<option> markers.
+type markerOptions struct {
+	Inline               bool     // +schema:inline
+	ID                   string   // +schema:id=URL
+	File                 string   // +schema:file=name.schema.json
+	AdditionalProperties string   // +schema:additional-properties=Field
+	Type                 string   // +schema:type=T on field comments
+	AnyOf                []string // +schema:anyof A B C on field comments
+	Dependent            []string // +schema:dependent=a:b,c, one per marker line
+	Parameters           bool     // +schema:parameters
+}
+
+// structMarker checks the type and declaration doc comments for +schema
+// markers and merges their options, preferring the type-level doc. The id
+// and file of a declaration doc only apply if it declares a single type,
+// since they identify one schema.
+func structMarker(groupDoc, typeDoc *ast.CommentGroup, single bool) (bool, markerOptions) {
+	typeFound, typeOpts := parseSchemaMarker(typeDoc)
+	groupFound, groupOpts := parseSchemaMarker(groupDoc)
+
+	opts := typeOpts
+	opts.Inline = typeOpts.Inline || groupOpts.Inline
+	opts.Parameters = typeOpts.Parameters || groupOpts.Parameters
+	if opts.ID == "" && single {
+		opts.ID = groupOpts.ID
+	}
+	if opts.File == "" && single {
+		opts.File = groupOpts.File
+	}
+	if opts.AdditionalProperties == "" {
+		opts.AdditionalProperties = groupOpts.AdditionalProperties
 	}
-	hasMarker, _ = parseSchemaMarker(groupDoc)
-	return hasMarker
+	opts.Dependent = append(opts.Dependent, groupOpts.Dependent...)
+	return typeFound || groupFound, opts
 }
 
-// parseSchemaMarker checks for +schema marker and extracts options.
-// Returns: hasMarker bool, inline bool
-func parseSchemaMarker(cg *ast.CommentGroup) (bool, bool) {
+// parseSchemaMarker checks for +schema markers and extracts their options.
+// Each marker line carries at most one option (+schema:inline, +schema:id=URL),
+// optionally followed by a description.
+func parseSchemaMarker(cg *ast.CommentGroup) (bool, markerOptions) {
+	var opts markerOptions
 	if cg == nil {
-		return false, false
+		return false, opts
 	}
-	for _, c := range cg.List {
-		text := c.Text
-		// Handle both // and /* */ comments
-		text = strings.TrimPrefix(text, "//")
-		text = strings.TrimPrefix(text, "/*")
-		text = strings.TrimSuffix(text, "*/")
-		text = strings.TrimSpace(text)
 
-		if text == SchemaMarker {
-			return true, false // +schema without inline
-		}
-		if text == SchemaMarker+":inline" || strings.HasPrefix(text, SchemaMarker+":inline ") {
-			return true, true // +schema:inline
-		}
-		if strings.HasPrefix(text, SchemaMarker+" ") {
-			return true, false // +schema with description
+	found := false
+	for _, c := range cg.List {
+		text := commentLine(c.Text)
+		if !isMarkerLine(text) {
+			continue
 		}
+		found = true
+
+		option, ok := strings.CutPrefix(text, SchemaMarker+":")
+		if !ok {
+			continue // +schema, optionally with description
+		}
+		option, rest, _ := strings.Cut(option, " ")
+		key, value, _ := strings.Cut(option, "=")
+		switch key {
+		case "inline":
+			opts.Inline = true
+		case "parameters":
+			opts.Parameters = true
+		case "id":
+			opts.ID = value
+		case "file":
+			opts.File = value
+		case "additional-properties":
+			opts.AdditionalProperties = value
+		case "type":
+			opts.Type = value
+		case "dependent":
+			opts.Dependent = append(opts.Dependent, value)
+		case "anyof":
+			// Type names follow the option instead of a description
+			opts.AnyOf = strings.Fields(rest)
+		}
+	}
+	return found, opts
+}
+
+// commentLine strips comment delimiters and surrounding whitespace.
+func commentLine(text string) string {
+	// Handle both // and /* */ comments
+	text = strings.TrimPrefix(text, "//")
+	text = strings.TrimPrefix(text, "/*")
+	text = strings.TrimSuffix(text, "*/")
+	return strings.TrimSpace(text)
+}
+
+// isMarkerLine reports whether a comment line is a +schema marker.
+func isMarkerLine(text string) bool {
+	return text == SchemaMarker ||
+		strings.HasPrefix(text, SchemaMarker+" ") ||
+		strings.HasPrefix(text, SchemaMarker+":")
+}
+
+// isExcluded reports whether a field matches the excluded fields.
+func (p *Parser) isExcluded(field FieldInfo) bool {
+	if p.excludeFields == nil {
+		return false
 	}
-	return false, false
+	return p.excludeFields.MatchString(field.PropertyName) || p.excludeFields.MatchString(field.Name)
 }
 
 // parseStruct parses a struct type specification.
 func (p *Parser) parseStruct(typeSpec *ast.TypeSpec, structType *ast.StructType, packageName, filePath string, doc *ast.CommentGroup) StructInfo {
+	p.pkg = packageName
 	info := StructInfo{
-		Name:     typeSpec.Name.Name,
+		Name:     p.typeKey(packageName, typeSpec.Name.Name),
+		GoName:   p.goName(typeSpec.Name.Name),
 		Package:  packageName,
 		FilePath: filePath,
-		Doc:      extractStructDoc(doc, typeSpec.Doc),
+		Doc:      p.extractStructDoc(doc, typeSpec.Doc),
 	}
 
 	if structType.Fields != nil {
 		for _, field := range structType.Fields.List {
 			fieldInfos := p.parseField(field, p.nameTag)
 			for _, fi := range fieldInfos {
-				// Skip fields marked with "-" in the tag
-				if fi.PropertyName == "-" {
+				// Skip fields marked with "-" in the tag and excluded fields
+				if fi.PropertyName == "-" || p.isExcluded(fi) {
 					continue
 				}
 				info.Fields = append(info.Fields, fi)
@@ -330,42 +591,137 @@ func (p *Parser) parseStruct(typeSpec *ast.TypeSpec, structType *ast.StructType,
 	return info
 }
 
+// catchAllField returns the field named by +schema:additional-properties and
+// removes it from the struct's properties. The field may be excluded from
+// JSON (json:"-"), as catch-all maps are usually filled by custom unmarshaling.
+func (p *Parser) catchAllField(structType *ast.StructType, fields []FieldInfo, name string) ([]FieldInfo, *FieldInfo) {
+	if structType.Fields == nil {
+		return fields, nil
+	}
+	for _, field := range structType.Fields.List {
+		for _, fi := range p.parseField(field, p.nameTag) {
+			if fi.Name != name {
+				continue
+			}
+			fields = slices.DeleteFunc(fields, func(f FieldInfo) bool { return f.Name == name })
+			return fields, &fi
+		}
+	}
+	return fields, nil
+}
+
+// dependentRequired resolves +schema:dependent=a:b,c markers to property
+// names: if a is present, b and c are required. Fields may be given by Go
+// or property name; invalid markers and unknown fields are reported and skipped.
+func (p *Parser) dependentRequired(structInfo StructInfo, specs []string) map[string][]string {
+	propertyName := func(name string) (string, bool) {
+		for _, field := range structInfo.Fields {
+			if field.Name == name || field.PropertyName == name {
+				return field.PropertyName, true
+			}
+		}
+		p.warnf("struct %s: dependent field %q not found", structInfo.Name, name)
+		return "", false
+	}
+
+	dependent := make(map[string][]string)
+	for _, spec := range specs {
+		trigger, required, ok := strings.Cut(spec, ":")
+		if !ok || trigger == "" || required == "" {
+			p.warnf("struct %s: invalid dependent %q: must be field:required,...", structInfo.Name, spec)
+			continue
+		}
+		key, ok := propertyName(trigger)
+		if !ok {
+			continue
+		}
+		for _, name := range strings.Split(required, ",") {
+			if prop, ok := propertyName(strings.TrimSpace(name)); ok && !slices.Contains(dependent[key], prop) {
+				dependent[key] = append(dependent[key], prop)
+			}
+		}
+	}
+	if len(dependent) == 0 {
+		return nil
+	}
+	return dependent
+}
+
+// parseStructAlias parses an alias to another struct in the same package (type A = B).
+// The alias target is resolved by the generator once all structs are known.
+func (p *Parser) parseStructAlias(typeSpec *ast.TypeSpec, packageName, filePath string, doc *ast.CommentGroup) (StructInfo, bool) {
+	if !typeSpec.Assign.IsValid() {
+		return StructInfo{}, false
+	}
+
+	ident, ok := typeSpec.Type.(*ast.Ident)
+	if !ok || !ident.IsExported() {
+		return StructInfo{}, false
+	}
+
+	return StructInfo{
+		Name:     p.typeKey(packageName, typeSpec.Name.Name),
+		GoName:   p.goName(typeSpec.Name.Name),
+		Package:  packageName,
+		FilePath: filePath,
+		Doc:      p.extractStructDoc(doc, typeSpec.Doc),
+		AliasOf:  p.typeKey(packageName, ident.Name),
+	}, true
+}
+
+// parseCollection parses a named slice, array or map type (type Users []User).
+func (p *Parser) parseCollection(typeSpec *ast.TypeSpec, packageName, filePath string, doc *ast.CommentGroup) (StructInfo, bool) {
+	if typeSpec.Assign.IsValid() {
+		return StructInfo{}, false
+	}
+
+	switch typeSpec.Type.(type) {
+	case *ast.ArrayType, *ast.MapType:
+	default:
+		return StructInfo{}, false
+	}
+
+	p.pkg = packageName
+	typeInfo := p.parseTypeExpr(typeSpec.Type)
+	return StructInfo{
+		Name:       p.typeKey(packageName, typeSpec.Name.Name),
+		GoName:     p.goName(typeSpec.Name.Name),
+		Package:    packageName,
+		FilePath:   filePath,
+		Doc:        p.extractStructDoc(doc, typeSpec.Doc),
+		Collection: &typeInfo,
+	}, true
+}
+
 // extractStructDoc extracts documentation for a struct.
-func extractStructDoc(groupDoc, typeDoc *ast.CommentGroup) string {
+func (p *Parser) extractStructDoc(groupDoc, typeDoc *ast.CommentGroup) string {
 	// Prefer type-level doc
 	if typeDoc != nil {
-		return extractCommentText(typeDoc)
+		return p.extractCommentText(typeDoc)
 	}
 	// Fall back to declaration-level doc
 	if groupDoc != nil {
-		return extractCommentText(groupDoc)
+		return p.extractCommentText(groupDoc)
 	}
 	return ""
 }
 
 // extractCommentText extracts text from a comment group.
-func extractCommentText(cg *ast.CommentGroup) string {
+func (p *Parser) extractCommentText(cg *ast.CommentGroup) string {
 	if cg == nil {
 		return ""
 	}
 
 	var lines []string
 	for _, c := range cg.List {
-		text := c.Text
-		text = strings.TrimPrefix(text, "//")
-		text = strings.TrimPrefix(text, "/*")
-		text = strings.TrimSuffix(text, "*/")
-		text = strings.TrimSpace(text)
-		// Skip empty lines, go directives, and schema markers
-		if text == "" || strings.HasPrefix(text, "go:") {
-			continue
-		}
-		if text == SchemaMarker || strings.HasPrefix(text, SchemaMarker+" ") {
+		text := commentLine(c.Text)
+		// Skip tool directives and schema markers; empty lines separate paragraphs
+		if p.isDirectiveLine(text) || isMarkerLine(text) {
 			continue
 		}
 		lines = append(lines, text)
 	}
-	return strings.Join(lines, " ")
+	return p.joinComment(lines)
 }
 
 // parseTypeExpr converts an AST type expression to TypeInfo.
@@ -453,8 +809,11 @@ func (p *Parser) parseIdent(ident *ast.Ident) TypeInfo {
 	case "any":
 		return TypeInfo{Kind: TypeKindInterface, Name: name}
 	default:
-		// Check type registry for aliases (e.g., type MyEnum string)
+		// Check type registry for interfaces and aliases (e.g., type MyEnum string)
 		if decl, ok := p.typeRegistry[name]; ok {
+			if decl.UnderlyingKind == TypeKindInterface {
+				return TypeInfo{Kind: TypeKindInterface, Name: name, IsExported: ast.IsExported(name)}
+			}
 			return TypeInfo{
 				Kind:           TypeKindAlias,
 				Name:           name,
@@ -464,10 +823,10 @@ func (p *Parser) parseIdent(ident *ast.Ident) TypeInfo {
 			}
 		}
 
-		// Named type (struct reference)
+		// Named type (struct reference), declared in the same package
 		return TypeInfo{
 			Kind:       TypeKindStruct,
-			Name:       name,
+			Name:       p.typeKey(p.pkg, name),
 			IsExported: ast.IsExported(name),
 		}
 	}
@@ -484,21 +843,15 @@ func (p *Parser) parseSelectorExpr(sel *ast.SelectorExpr) TypeInfo {
 	typeName := sel.Sel.Name
 	fullName := pkgName + "." + typeName
 
-	// Special case for time.Time
-	if pkgName == "time" && typeName == "Time" {
+	// Well-known types with a fixed JSON representation (time.Time, sql.NullString, ...)
+	if known, ok := p.knownTypes[fullName]; ok {
 		return TypeInfo{
-			Kind:        TypeKindTime,
-			Name:        fullName,
-			PackageName: pkgName,
-		}
-	}
-
-	// Special case for time.Duration
-	if pkgName == "time" && typeName == "Duration" {
-		return TypeInfo{
-			Kind:        TypeKindDuration,
-			Name:        fullName,
-			PackageName: pkgName,
+			Kind:           known.Kind,
+			Name:           fullName,
+			PackageName:    pkgName,
+			UnderlyingKind: TypeKindPrimitive,
+			UnderlyingName: known.UnderlyingName,
+			Nullable:       known.Nullable,
 		}
 	}
 
@@ -511,6 +864,24 @@ func (p *Parser) parseSelectorExpr(sel *ast.SelectorExpr) TypeInfo {
 	}
 }
 
+// typeKey returns the name identifying a struct of the given package: the
+// type name, or pkg_Name with qualified names.
+func (p *Parser) typeKey(packageName, name string) string {
+	if !p.qualify || packageName == "" {
+		return name
+	}
+	return packageName + "_" + name
+}
+
+// goName returns the Go type name of a struct whose name is qualified, or ""
+// if names are not qualified.
+func (p *Parser) goName(name string) string {
+	if !p.qualify {
+		return ""
+	}
+	return name
+}
+
 // FindStructByName finds a specific exported struct by name without requiring the +schema annotation.
 // This is used to resolve referenced types that aren't explicitly annotated.
 func (p *Parser) FindStructByName(path string, name string, recursive bool) (*StructInfo, error) {
@@ -542,7 +913,7 @@ func (p *Parser) findStructInDirRecursive(root string, name string) (*StructInfo
 			return nil
 		}
 
-		if shouldSkipDir(d.Name()) {
+		if p.skipWalkDir(root, path, d.Name()) {
 			return filepath.SkipDir
 		}
 
@@ -566,23 +937,17 @@ func (p *Parser) findStructInDirRecursive(root string, name string) (*StructInfo
 
 // findStructInDir searches for a struct by name in a single directory.
 func (p *Parser) findStructInDir(dir string, name string) (*StructInfo, error) {
-	entries, err := os.ReadDir(dir)
+	filePaths, err := p.SourceFiles(dir)
 	if err != nil {
-		return nil, fmt.Errorf("read directory %s: %w", dir, err)
+		return nil, err
 	}
 
-	for _, entry := range entries {
-		if entry.IsDir() {
-			continue
-		}
-		if !strings.HasSuffix(entry.Name(), ".go") {
-			continue
-		}
-		if strings.HasSuffix(entry.Name(), "_test.go") {
-			continue
-		}
+	// Register the types of all files first, as in parseDirectory
+	for _, filePath := range filePaths {
+		_, _ = p.loadFile(filePath)
+	}
 
-		filePath := filepath.Join(dir, entry.Name())
+	for _, filePath := range filePaths {
 		found, err := p.findStructInFile(filePath, name)
 		if err != nil {
 			continue
@@ -597,21 +962,13 @@ func (p *Parser) findStructInDir(dir string, name string) (*StructInfo, error) {
 
 // findStructInFile searches for a struct by name in a single file.
 func (p *Parser) findStructInFile(filePath string, name string) (*StructInfo, error) {
-	src, err := os.ReadFile(filePath)
-	if err != nil {
-		return nil, fmt.Errorf("read file %s: %w", filePath, err)
-	}
-
-	file, err := parser.ParseFile(p.fset, filePath, src, parser.ParseComments)
-	if err != nil {
-		return nil, fmt.Errorf("parse file %s: %w", filePath, err)
+	file, err := p.loadFile(filePath)
+	if err != nil || file == nil {
+		return nil, err
 	}
 
 	packageName := file.Name.Name
 
-	// Extract type declarations for registry
-	p.extractTypeDecls(file)
-
 	for _, decl := range file.Decls {
 		genDecl, ok := decl.(*ast.GenDecl)
 		if !ok {
@@ -631,18 +988,14 @@ func (p *Parser) findStructInFile(filePath string, name string) (*StructInfo, er
 			if !typeSpec.Name.IsExported() {
 				continue
 			}
-			if typeSpec.Name.Name != name {
-				continue
-			}
-
-			structType, ok := typeSpec.Type.(*ast.StructType)
-			if !ok {
+			if p.typeKey(packageName, typeSpec.Name.Name) != name {
 				continue
 			}
 
 			// Parse the struct without requiring +schema annotation
-			structInfo := p.parseStruct(typeSpec, structType, packageName, filePath, genDecl.Doc)
-			return &structInfo, nil
+			if structInfo := p.structFromSpec(typeSpec, packageName, filePath, genDecl.Doc); structInfo != nil {
+				return structInfo, nil
+			}
 		}
 	}
 
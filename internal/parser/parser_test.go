@@ -0,0 +1,43 @@
+package parser
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+// TestFilesParsedOnce checks that parsing a directory and resolving
+// references from it reads every file exactly once.
+func TestFilesParsedOnce(t *testing.T) {
+	dir := filepath.Join("testdata", "index")
+
+	p := NewParser(Config{})
+	parses := make(map[string]int)
+	p.onParse = func(filePath string) { parses[filepath.Base(filePath)]++ }
+
+	if _, err := p.ParsePath(dir); err != nil {
+		t.Fatal(err)
+	}
+	// Reference resolution as done by the generator: index lookups with a
+	// fallback to searching the path
+	for _, name := range []string{"Address", "Tags", "Customer", "Point", "Missing"} {
+		p.LookupStruct(name)
+		if _, err := p.FindStructByName(dir, name, false); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := p.FindStructByName(filepath.Join(dir, "types.go"), name, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := p.FindStructByName(dir, "Point", true); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"models.go", "types.go"} {
+		if parses[name] != 1 {
+			t.Errorf("%s parsed %d times, want 1", name, parses[name])
+		}
+	}
+	if len(parses) != 2 {
+		t.Errorf("parsed files = %v, want models.go and types.go", parses)
+	}
+}
@@ -0,0 +1,14 @@
+package models
+
+// User is annotated.
+// +schema
+type User struct {
+	Name    string  `json:"name"`
+	Address Address `json:"address"`
+	Tags    Tags    `json:"tags"`
+}
+
+// Address is only referenced.
+type Address struct {
+	City string `json:"city"`
+}
@@ -0,0 +1,16 @@
+package models
+
+// Tags is a named collection.
+type Tags []string
+
+// Customer is an alias of User.
+type Customer = User
+
+type (
+	// Point is declared in a group.
+	Point struct {
+		X, Y int
+	}
+
+	hidden struct{}
+)
@@ -0,0 +1,54 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseTypeMappings parses a comma-separated list of type mappings in the form
+// "pkg.Type=target[:nullable]", e.g. "null.String=string:nullable,decimal.Decimal=string".
+func ParseTypeMappings(spec string) (map[string]TypeMapping, error) {
+	mappings := make(map[string]TypeMapping)
+
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		name, target, ok := strings.Cut(entry, "=")
+		if !ok || !strings.Contains(name, ".") {
+			return nil, fmt.Errorf("invalid type mapping %q: expected pkg.Type=target[:nullable]", entry)
+		}
+
+		mapping := TypeMapping{Target: target}
+		if base, opt, hasOpt := strings.Cut(target, ":"); hasOpt {
+			if opt != "nullable" {
+				return nil, fmt.Errorf("invalid type mapping %q: unknown option %q", entry, opt)
+			}
+			mapping.Target = base
+			mapping.Nullable = true
+		}
+
+		if _, ok := mapping.resolve(); !ok {
+			return nil, fmt.Errorf("invalid type mapping %q: unsupported target %q", entry, mapping.Target)
+		}
+		mappings[strings.TrimSpace(name)] = mapping
+	}
+
+	return mappings, nil
+}
+
+// resolve converts the mapping into a known type representation.
+// Targets are either Go primitives or built-in known types such as time.Time.
+func (m TypeMapping) resolve() (knownType, bool) {
+	if known, ok := knownTypes[m.Target]; ok {
+		known.Nullable = known.Nullable || m.Nullable
+		return known, true
+	}
+
+	if kind, name := new(Parser).classifyPrimitive(m.Target); kind != TypeKindUnknown {
+		return knownType{Kind: TypeKindAlias, UnderlyingName: name, Nullable: m.Nullable}, true
+	}
+	return knownType{}, false
+}
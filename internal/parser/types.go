@@ -30,6 +30,35 @@ type TypeInfo struct {
 	IsExported     bool      // Whether the type name is exported
 	UnderlyingKind TypeKind  // For aliases: the underlying type's kind
 	UnderlyingName string    // For aliases: the underlying type's name (e.g., "string", "int")
+	Nullable       bool      // Whether the type's JSON value may also be null (e.g., sql.NullString)
+}
+
+// knownType describes the JSON representation of a well-known external type.
+type knownType struct {
+	Kind           TypeKind // TypeKindTime, TypeKindDuration, or TypeKindAlias for primitives
+	UnderlyingName string   // For TypeKindAlias: the primitive the value is represented as
+	Nullable       bool     // Whether the value may be null
+}
+
+// knownTypes maps qualified external type names to their JSON representation.
+var knownTypes = map[string]knownType{
+	"time.Time":       {Kind: TypeKindTime},
+	"time.Duration":   {Kind: TypeKindDuration},
+	"sql.NullString":  {Kind: TypeKindAlias, UnderlyingName: "string", Nullable: true},
+	"sql.NullInt64":   {Kind: TypeKindAlias, UnderlyingName: "int64", Nullable: true},
+	"sql.NullInt32":   {Kind: TypeKindAlias, UnderlyingName: "int32", Nullable: true},
+	"sql.NullInt16":   {Kind: TypeKindAlias, UnderlyingName: "int16", Nullable: true},
+	"sql.NullByte":    {Kind: TypeKindAlias, UnderlyingName: "byte", Nullable: true},
+	"sql.NullFloat64": {Kind: TypeKindAlias, UnderlyingName: "float64", Nullable: true},
+	"sql.NullBool":    {Kind: TypeKindAlias, UnderlyingName: "bool", Nullable: true},
+	"sql.NullTime":    {Kind: TypeKindTime, Nullable: true},
+	"json.RawMessage": {Kind: TypeKindInterface}, // Any JSON value
+}
+
+// TypeMapping maps an external type (e.g., null.String) to its JSON representation.
+type TypeMapping struct {
+	Target   string // Go primitive (string, int64, ...) or known type (time.Time) the value is represented as
+	Nullable bool   // Whether the value may also be null
 }
 
 // TypeDecl represents a type declaration (e.g., type MyEnum string).
@@ -41,13 +70,30 @@ type TypeDecl struct {
 
 // StructInfo holds parsed information about a Go struct.
 type StructInfo struct {
-	Name        string
+	Name        string // Type name, or pkg_Name with qualified names
+	GoName      string // Go type name if Name is qualified
 	Package     string // Package name
 	PackagePath string // Full package import path
 	Fields      []FieldInfo
 	Doc         string // Comment above struct
 	FilePath    string // Source file path
 	Inline      bool   // Per-struct inline preference from +schema:inline
+	AliasOf     string // Target struct name for aliases (type A = B)
+	ID          string // Custom $id from +schema:id=URL, overriding --schema-id
+	File        string // Custom schema filename from +schema:file=name.schema.json
+	Parameters  bool   // Emitted as OpenAPI parameters from +schema:parameters
+
+	// Collection is the type of a named slice, array or map (type Users []User),
+	// generated as a root schema of that type instead of an object
+	Collection *TypeInfo
+
+	// AdditionalProperties is the catch-all map field named by
+	// +schema:additional-properties=Field, whose value schema allows extra properties
+	AdditionalProperties *FieldInfo
+
+	// DependentRequired maps a property to the properties required when it
+	// is present, from +schema:dependent=a:b,c markers
+	DependentRequired map[string][]string
 }
 
 // FieldInfo holds parsed information about a struct field.
@@ -58,7 +104,18 @@ type FieldInfo struct {
 	Tags         map[string]string // All struct tags (validate, json, etc.)
 	Doc          string            // Comment above or beside field
 	IsEmbedded   bool              // Whether this is an embedded field
+	Promoted     bool              // Embedded without a name tag, so struct fields are promoted
+	Squash       bool              // Embedded with an inline/squash tag option, always flattened
 	OmitEmpty    bool              // Whether json tag has omitempty
+	AnyOf        []string          // Types from a +schema:anyof marker the value may match
+}
+
+// TypeName returns the Go type name of the struct, without package qualifier.
+func (s StructInfo) TypeName() string {
+	if s.GoName != "" {
+		return s.GoName
+	}
+	return s.Name
 }
 
 // IsPrimitive returns true if the type is a Go primitive.
@@ -0,0 +1,437 @@
+// Package protobuf parses Protocol Buffers definitions into the struct model
+// consumed by the schema builder.
+package protobuf
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/emicklei/proto"
+	"github.com/invopop/jsonschema"
+	"github.com/ron96g/json-schema-gen/internal/parser"
+)
+
+// Extension is the file extension of Protocol Buffers definitions.
+const Extension = ".proto"
+
+// scalarTypes maps proto scalar types to the Go types protoc-gen-go emits.
+var scalarTypes = map[string]string{
+	"double":   "float64",
+	"float":    "float32",
+	"int32":    "int32",
+	"sint32":   "int32",
+	"sfixed32": "int32",
+	"int64":    "int64",
+	"sint64":   "int64",
+	"sfixed64": "int64",
+	"uint32":   "uint32",
+	"fixed32":  "uint32",
+	"uint64":   "uint64",
+	"fixed64":  "uint64",
+	"bool":     "bool",
+	"string":   "string",
+	"bytes":    "string", // base64 encoded in JSON
+}
+
+// int64Patterns are the patterns of the decimal strings protojson encodes
+// 64-bit integers as, by the Go type of the scalar.
+var int64Patterns = map[string]string{
+	"int64":  "^-?[0-9]+$",
+	"uint64": "^[0-9]+$",
+}
+
+// TypeHandler maps 64-bit integers to strings, since protojson encodes them
+// as decimal strings to avoid losing precision in JavaScript.
+func TypeHandler(typeInfo parser.TypeInfo) *jsonschema.Schema {
+	if typeInfo.Kind != parser.TypeKindPrimitive {
+		return nil
+	}
+	pattern, ok := int64Patterns[typeInfo.Name]
+	if !ok {
+		return nil
+	}
+	return &jsonschema.Schema{Type: "string", Pattern: pattern}
+}
+
+// wellKnownTypes maps well-known message types to their JSON representation.
+var wellKnownTypes = map[string]parser.TypeInfo{
+	"google.protobuf.Timestamp": {Kind: parser.TypeKindTime, Name: "google.protobuf.Timestamp"},
+	// Encoded as seconds with an "s" suffix (e.g., "1.5s"), not ISO 8601
+	"google.protobuf.Duration": {Kind: parser.TypeKindPrimitive, Name: "string"},
+}
+
+// Parser converts proto messages into StructInfo values. Nested messages
+// and enums are named after their Go types (Outer_Inner).
+type Parser struct {
+	messages map[string]parser.StructInfo // All parsed messages by name, including nested ones
+	enums    map[string][]parser.EnumValue
+	decls    map[string]bool // Declared message (false) and enum (true) names
+}
+
+// NewParser creates a new Parser instance.
+func NewParser() *Parser {
+	return &Parser{
+		messages: make(map[string]parser.StructInfo),
+		enums:    make(map[string][]parser.EnumValue),
+		decls:    make(map[string]bool),
+	}
+}
+
+// protoFile is a parsed definition file.
+type protoFile struct {
+	path string
+	pkg  string
+	def  *proto.Proto
+}
+
+// ParsePathWithOptions parses .proto files from a path (file or directory).
+// All top-level messages are returned; nested messages are only resolved
+// via LookupStruct when referenced.
+func (p *Parser) ParsePathWithOptions(path string, recursive bool) ([]parser.StructInfo, error) {
+	paths, err := protoFiles(path, recursive)
+	if err != nil {
+		return nil, err
+	}
+
+	// Declarations are collected first, so fields can refer to types of any file
+	files := make([]protoFile, 0, len(paths))
+	for _, path := range paths {
+		file, err := parseFile(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, elem := range file.def.Elements {
+			p.declare(elem, "")
+		}
+		files = append(files, file)
+	}
+
+	var structs []parser.StructInfo
+	for _, file := range files {
+		for _, elem := range file.def.Elements {
+			switch e := elem.(type) {
+			case *proto.Message:
+				structs = append(structs, p.parseMessage(file, e, nil))
+			case *proto.Enum:
+				p.parseEnum(e, "")
+			}
+		}
+	}
+	return structs, nil
+}
+
+// FindStructByName parses the path and returns the message with the given
+// name, or nil if none was found.
+func (p *Parser) FindStructByName(path string, name string, recursive bool) (*parser.StructInfo, error) {
+	if _, err := p.ParsePathWithOptions(path, recursive); err != nil {
+		return nil, err
+	}
+	return p.LookupStruct(name), nil
+}
+
+// LookupStruct returns a message parsed so far, or nil if none was seen.
+func (p *Parser) LookupStruct(name string) *parser.StructInfo {
+	msg, ok := p.messages[name]
+	if !ok {
+		return nil
+	}
+	return &msg
+}
+
+// Marshalers returns no types, since messages never marshal themselves.
+func (p *Parser) Marshalers() map[string]parser.MarshalerKind {
+	return nil
+}
+
+// Enums returns the values of all parsed enums by name.
+func (p *Parser) Enums() map[string][]parser.EnumValue {
+	return p.enums
+}
+
+// SourceFiles returns the .proto files in a directory.
+func (p *Parser) SourceFiles(dir string) ([]string, error) {
+	return protoFiles(dir, false)
+}
+
+// protoFiles returns the .proto files of a path, descending into
+// subdirectories if recursive.
+func protoFiles(path string, recursive bool) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("stat path %s: %w", path, err)
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	var files []string
+	err = filepath.WalkDir(path, func(file string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if file != path && !recursive {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(file) == Extension {
+			files = append(files, file)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("walk directory %s: %w", path, err)
+	}
+	return files, nil
+}
+
+// parseFile parses a single .proto file.
+func parseFile(path string) (protoFile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return protoFile{}, fmt.Errorf("open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	p := proto.NewParser(f)
+	p.Filename(path)
+	def, err := p.Parse()
+	if err != nil {
+		return protoFile{}, fmt.Errorf("parse %s: %w", path, err)
+	}
+
+	file := protoFile{path: path, def: def}
+	for _, elem := range def.Elements {
+		if pkg, ok := elem.(*proto.Package); ok {
+			file.pkg = pkg.Name
+		}
+	}
+	return file, nil
+}
+
+// declare registers the messages and enums declared by elem and its children.
+func (p *Parser) declare(elem proto.Visitee, scope string) {
+	switch e := elem.(type) {
+	case *proto.Message:
+		name := scope + e.Name
+		p.decls[name] = false
+		for _, child := range e.Elements {
+			p.declare(child, name+"_")
+		}
+	case *proto.Enum:
+		p.decls[scope+e.Name] = true
+	}
+}
+
+// parseMessage converts a message and records it and its nested messages
+// and enums. outer holds the names of the enclosing messages.
+func (p *Parser) parseMessage(file protoFile, msg *proto.Message, outer []string) parser.StructInfo {
+	name := msg.Name
+	if len(outer) > 0 {
+		name = outer[len(outer)-1] + "_" + msg.Name
+	}
+	scopes := append(slices.Clone(outer), name)
+	info := parser.StructInfo{
+		Name:     name,
+		Package:  file.pkg,
+		Doc:      commentText(msg.Comment),
+		FilePath: file.path,
+	}
+
+	for _, elem := range msg.Elements {
+		switch e := elem.(type) {
+		case *proto.NormalField:
+			typeInfo := p.resolveType(file.pkg, scopes, e.Type)
+			if e.Repeated {
+				typeInfo = sliceOf(typeInfo)
+			}
+			field := p.field(e.Field, typeInfo)
+			if e.Required {
+				field.Tags["validate"] = "required"
+				field.OmitEmpty = false
+			}
+			info.Fields = append(info.Fields, field)
+
+		case *proto.MapField:
+			key := scalarType(e.KeyType)
+			value := p.resolveType(file.pkg, scopes, e.Type)
+			typeInfo := parser.TypeInfo{
+				Kind:     parser.TypeKindMap,
+				Name:     fmt.Sprintf("map[%s]%s", key.Name, value.Name),
+				KeyType:  &key,
+				ElemType: &value,
+			}
+			info.Fields = append(info.Fields, p.field(e.Field, typeInfo))
+
+		case *proto.Oneof:
+			// Members of a oneof are optional fields of the message itself
+			for _, member := range e.Elements {
+				if f, ok := member.(*proto.OneOfField); ok {
+					info.Fields = append(info.Fields, p.field(f.Field, p.resolveType(file.pkg, scopes, f.Type)))
+				}
+			}
+
+		case *proto.Message:
+			p.parseMessage(file, e, scopes)
+
+		case *proto.Enum:
+			p.parseEnum(e, name+"_")
+		}
+	}
+
+	p.messages[name] = info
+	return info
+}
+
+// field converts a message field. Fields with default values are omitted
+// from JSON, so they are never required unless marked as such.
+func (p *Parser) field(f *proto.Field, typeInfo parser.TypeInfo) parser.FieldInfo {
+	field := parser.FieldInfo{
+		Name:         goFieldName(f.Name),
+		PropertyName: jsonName(f),
+		Type:         typeInfo,
+		Tags:         make(map[string]string),
+		Doc:          commentText(f.Comment),
+		OmitEmpty:    true,
+	}
+	if field.Doc == "" {
+		field.Doc = commentText(f.InlineComment)
+	}
+	if f.Type == "bytes" {
+		field.Tags["validate"] = "base64"
+	}
+	return field
+}
+
+// parseEnum records the values of an enum. Enums are encoded by value name.
+func (p *Parser) parseEnum(enum *proto.Enum, scope string) {
+	name := scope + enum.Name
+	var values []parser.EnumValue
+	for _, elem := range enum.Elements {
+		if f, ok := elem.(*proto.EnumField); ok {
+			values = append(values, parser.EnumValue{
+				Name:  f.Name,
+				Value: f.Name,
+				Doc:   commentText(f.Comment),
+			})
+		}
+	}
+	p.enums[name] = values
+}
+
+// resolveType resolves a field type referenced from a message, searching the
+// message and its enclosing messages (scopes) from the innermost outwards.
+func (p *Parser) resolveType(pkg string, scopes []string, typeName string) parser.TypeInfo {
+	if _, ok := scalarTypes[typeName]; ok {
+		return scalarType(typeName)
+	}
+
+	fullName := strings.TrimPrefix(typeName, ".")
+	if known, ok := wellKnownTypes[fullName]; ok {
+		return known
+	}
+	if pkg != "" {
+		fullName = strings.TrimPrefix(fullName, pkg+".")
+	}
+	ref := strings.ReplaceAll(fullName, ".", "_")
+
+	for i := len(scopes); i >= 0; i-- {
+		candidate := ref
+		if i > 0 {
+			candidate = scopes[i-1] + "_" + ref
+		}
+		if isEnum, ok := p.decls[candidate]; ok {
+			if isEnum {
+				return parser.TypeInfo{
+					Kind:           parser.TypeKindAlias,
+					Name:           candidate,
+					IsExported:     true,
+					UnderlyingKind: parser.TypeKindPrimitive,
+					UnderlyingName: "string",
+				}
+			}
+			return parser.TypeInfo{Kind: parser.TypeKindStruct, Name: candidate, IsExported: true}
+		}
+	}
+
+	// Types of other packages keep their qualified name and are not resolved
+	return parser.TypeInfo{Kind: parser.TypeKindStruct, Name: fullName, IsExported: true}
+}
+
+// scalarType returns the Go primitive of a scalar proto type.
+func scalarType(typeName string) parser.TypeInfo {
+	return parser.TypeInfo{Kind: parser.TypeKindPrimitive, Name: scalarTypes[typeName]}
+}
+
+// sliceOf returns a slice of the element type.
+func sliceOf(elem parser.TypeInfo) parser.TypeInfo {
+	return parser.TypeInfo{
+		Kind:     parser.TypeKindSlice,
+		Name:     "[]" + elem.Name,
+		ElemType: &elem,
+	}
+}
+
+// jsonName returns the JSON name of a field: the json_name option, or the
+// lowerCamelCase field name as used by protojson.
+func jsonName(f *proto.Field) string {
+	for _, opt := range f.Options {
+		if opt.Name == "json_name" {
+			return opt.Constant.Source
+		}
+	}
+
+	var b strings.Builder
+	upper := false
+	for _, r := range f.Name {
+		switch {
+		case r == '_':
+			upper = true
+		case upper:
+			b.WriteRune(unicode.ToUpper(r))
+			upper = false
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// goFieldName returns the Go field name protoc-gen-go generates (foo_bar
+// becomes FooBar).
+func goFieldName(name string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range name {
+		switch {
+		case r == '_':
+			upper = true
+		case upper:
+			b.WriteRune(unicode.ToUpper(r))
+			upper = false
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// commentText joins the lines of a comment into a description.
+func commentText(c *proto.Comment) string {
+	if c == nil {
+		return ""
+	}
+	var lines []string
+	for _, line := range c.Lines {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, " ")
+}
@@ -1,10 +1,13 @@
 package schema
 
 import (
-	"strings"
+	"fmt"
+	"path/filepath"
+	"regexp"
 
 	"github.com/invopop/jsonschema"
 	"github.com/ron96g/json-schema-gen/internal/parser"
+	orderedmap "github.com/wk8/go-ordered-map/v2"
 )
 
 const (
@@ -14,17 +17,225 @@ const (
 
 // Builder builds JSON Schemas from parsed struct information.
 type Builder struct {
-	mapper    *ValidatorMapper
-	schemaID  string                       // Base URL for $id field
-	structMap map[string]parser.StructInfo // Map of struct names for inline lookups
+	mapper             *ValidatorMapper
+	schemaID           string                           // Base URL for $id field
+	schemaURI          string                           // Root $schema value
+	openAPIVersion     string                           // OpenAPI version controlling pointer nullability
+	humanizeTitles     bool                             // Convert struct names to human-readable titles
+	intrinsicBounds    bool                             // Emit minimum/maximum from sized integer types
+	runeAsString       bool                             // Treat standalone rune fields as single characters
+	hoistThreshold     int                              // Inline refs used at least this often go to $defs (0 disables)
+	marshalerAs        string                           // Schema type for json.Marshaler types ("any" for {})
+	marshalers         map[string]parser.MarshalerKind  // Types implementing custom marshaling
+	warnedMarshalers   map[string]bool                  // Marshaler types already warned about
+	warned             map[string]bool                  // Warning messages already reported
+	enums              map[string][]parser.EnumValue    // Typed constants by type name
+	documentedEnums    bool                             // Emit commented enum values as oneOf const+description
+	enumVarnames       bool                             // Emit constant names of enums as x-enum-varnames
+	enumNames          bool                             // Emit labels derived from constant names as x-enumNames
+	warnf              func(format string, args ...any) // Reports non-fatal warnings
+	structMap          map[string]parser.StructInfo     // Map of struct names for inline lookups
+	extension          string                           // Schema file extension for $id and refs
+	baseRef            string                           // Base schema every root schema extends via allOf
+	refStyle           string                           // How $refs point to other schemas (file, defs or id)
+	nullablePointers   bool                             // Add "null" to the type of pointer fields
+	flattenSingleField bool                             // Replace refs to single-field structs with the field's schema
+	typeHandlers       []TypeHandler                    // Custom type handlers registered via RegisterTypeHandler
+	stamp              string                           // Generator name and version for x-generator
+	noRequired         bool                             // Never emit required arrays
+	embedMode          string                           // Embedded structs are flattened or composed via allOf
+	requiredNonEmpty   bool                             // Required slices and maps need at least one element
+	requiredStrings    bool                             // Required strings need at least one character
+	titleFromComment   bool                             // Use the first doc sentence as title
+	examplesFromEnum   bool                             // Emit the first enum value as example
+	readOnlyFields     *regexp.Regexp                   // Fields marked readOnly (nil for none)
+	warnUnmapped       bool                             // Warn about external types reduced to object
+	autoFieldTitles    bool                             // Title fields with their humanized property name
+	deterministicMaps  bool                             // Constrain map keys of enum and integer types via propertyNames
+	schemaComment      bool                             // Record the source file as $comment of root schemas
+
+	requiredUnlessOmitEmpty bool // Require all fields except pointers and omitempty fields
+}
+
+// Config holds builder configuration.
+type Config struct {
+	SchemaID        string   // Base URL for $id field
+	SchemaURI       string   // Root $schema value (default JSONSchemaDraft)
+	OpenAPIVersion  string   // OpenAPI version for nullable pointers ("3.0", "3.1", or empty to disable)
+	HumanizeTitles  bool     // Use "Service Config" instead of "ServiceConfig" as title
+	ValidationTags  []string // Tags to read validator rules from, in priority order
+	IntrinsicBounds bool     // Emit minimum/maximum for sized integer types (int8, uint16, ...)
+	RuneAsString    bool     // Emit standalone rune fields as single-character strings
+	HoistThreshold  int      // In inline mode, hoist types referenced at least N times into $defs (0 disables)
+	MarshalerAs     string   // Schema type for json.Marshaler types ("any", "string", "object", ...)
+	Extension       string   // Schema file extension (default ".schema.json")
+	BaseRef         string   // Wrap each root schema as allOf [{$ref: BaseRef}, {...}]
+	DocumentedEnums bool     // Emit commented enum values as oneOf const+description entries
+	EnumVarnames    bool     // Emit the constant names of const-derived enums as x-enum-varnames
+	EnumNames       bool     // Emit human labels derived from constant names as x-enumNames
+	RefStyle        string   // How $refs point to other schemas: RefStyleFile (default), RefStyleDefs or RefStyleID
+
+	// NullablePointers adds "null" to the type of pointer fields (["string", "null"]).
+	// OpenAPIVersion takes precedence if set.
+	NullablePointers bool
+
+	// FlattenSingleField replaces references to single-field wrapper structs
+	// with the schema of their field. Generated schemas of wrappers are unchanged.
+	FlattenSingleField bool
+
+	// Stamp is written as the x-generator extension of root schemas (e.g.,
+	// "json-schema-gen v1.2.3"). Empty disables it.
+	Stamp string
+
+	NoRequired bool // Suppress required arrays; validators still add their other constraints
+
+	// EmbedMode is EmbedModeFlatten (default) to promote the fields of embedded
+	// structs, or EmbedModeRef to compose the parent via allOf
+	EmbedMode string
+
+	// RequiredNonEmpty adds minItems/minProperties 1 to required slices and
+	// maps, since go-playground's required rejects empty collections
+	RequiredNonEmpty bool
+
+	// RequiredStringsNonEmpty adds minLength 1 to required strings, since
+	// go-playground's required rejects the empty string
+	RequiredStringsNonEmpty bool
+
+	// TitleFromComment uses the first sentence of a struct's doc comment as
+	// title and the remainder as description. Structs without doc keep their name.
+	TitleFromComment bool
+
+	ExamplesFromEnum bool // Emit examples with the first enum value of fields
+	AutoFieldTitles  bool // Title fields with their humanized property name (zip_code -> Zip Code)
+
+	// SchemaComment adds a $comment naming the tool and the source file a
+	// root schema was generated from
+	SchemaComment bool
+
+	// DeterministicMaps emits propertyNames for maps whose key type is an
+	// enum (its values in declaration order) or an integer (decimal digits)
+	DeterministicMaps bool
+
+	// WarnUnmappedTypes reports fields whose external types are emitted as a
+	// bare object because neither a known type nor a type mapping covers them
+	WarnUnmappedTypes bool
+
+	// ReadOnlyFields marks fields whose property or Go name fully matches as
+	// readOnly (server-managed fields such as id or created_at)
+	ReadOnlyFields *regexp.Regexp
+
+	// RequiredUnlessOmitEmpty requires every field that is neither a pointer
+	// nor tagged omitempty, with or without a required validator
+	RequiredUnlessOmitEmpty bool
 }
 
 // NewBuilder creates a new Builder.
-func NewBuilder(schemaID string) *Builder {
+func NewBuilder(cfg Config) *Builder {
+	marshalerAs := cfg.MarshalerAs
+	if marshalerAs == "" {
+		marshalerAs = MarshalerAsAny
+	}
+	embedMode := cfg.EmbedMode
+	if embedMode == "" {
+		embedMode = EmbedModeFlatten
+	}
+	refStyle := cfg.RefStyle
+	if refStyle == "" {
+		refStyle = RefStyleFile
+	}
+	schemaURI := cfg.SchemaURI
+	if schemaURI == "" {
+		schemaURI = JSONSchemaDraft
+	}
+
 	return &Builder{
-		mapper:   NewValidatorMapper(),
-		schemaID: schemaID,
+		mapper:             NewValidatorMapper(cfg.ValidationTags...),
+		schemaID:           cfg.SchemaID,
+		schemaURI:          schemaURI,
+		openAPIVersion:     cfg.OpenAPIVersion,
+		humanizeTitles:     cfg.HumanizeTitles,
+		intrinsicBounds:    cfg.IntrinsicBounds,
+		runeAsString:       cfg.RuneAsString,
+		hoistThreshold:     cfg.HoistThreshold,
+		marshalerAs:        marshalerAs,
+		warnedMarshalers:   make(map[string]bool),
+		warned:             make(map[string]bool),
+		extension:          cfg.Extension,
+		baseRef:            cfg.BaseRef,
+		documentedEnums:    cfg.DocumentedEnums,
+		enumVarnames:       cfg.EnumVarnames,
+		enumNames:          cfg.EnumNames,
+		refStyle:           refStyle,
+		nullablePointers:   cfg.NullablePointers,
+		flattenSingleField: cfg.FlattenSingleField,
+		stamp:              cfg.Stamp,
+		noRequired:         cfg.NoRequired,
+		embedMode:          embedMode,
+		requiredNonEmpty:   cfg.RequiredNonEmpty,
+		requiredStrings:    cfg.RequiredStringsNonEmpty,
+		titleFromComment:   cfg.TitleFromComment,
+		examplesFromEnum:   cfg.ExamplesFromEnum,
+		readOnlyFields:     cfg.ReadOnlyFields,
+		warnUnmapped:       cfg.WarnUnmappedTypes,
+		autoFieldTitles:    cfg.AutoFieldTitles,
+		deterministicMaps:  cfg.DeterministicMaps,
+		schemaComment:      cfg.SchemaComment,
+
+		requiredUnlessOmitEmpty: cfg.RequiredUnlessOmitEmpty,
+	}
+}
+
+// warnOnce reports a warning unless the same message was already reported.
+// Schemas are built more than once (ref analysis and output), so repeats are dropped.
+func (b *Builder) warnOnce(format string, args ...any) {
+	if b.warnf == nil {
+		return
+	}
+	msg := fmt.Sprintf(format, args...)
+	if b.warned[msg] {
+		return
 	}
+	b.warned[msg] = true
+	b.warnf("%s", msg)
+}
+
+// NewRefTracker creates a RefTracker producing ref paths for this builder's
+// file extension and ref style.
+func (b *Builder) NewRefTracker() *RefTracker {
+	return NewRefTracker(b.refStyle, b.extension, b.schemaID)
+}
+
+// WithExtension returns a copy of the builder emitting $id and $ref paths
+// with the given file extension, e.g. for writing the same schemas as YAML.
+func (b *Builder) WithExtension(extension string) *Builder {
+	clone := *b
+	clone.extension = extension
+	return &clone
+}
+
+// refPath returns the $ref for a struct. With id refs, a struct's custom
+// +schema:id is its absolute location and is used as-is. File refs point to
+// a custom +schema:file name.
+func (b *Builder) refPath(refTracker *RefTracker, typeName string) string {
+	structInfo := b.structMap[typeName]
+	if b.refStyle == RefStyleID && structInfo.ID != "" {
+		return structInfo.ID
+	}
+	if structInfo.File == "" || b.refStyle == RefStyleDefs {
+		return refTracker.GetRefPath(typeName)
+	}
+	if b.refStyle == RefStyleID && refTracker.baseURL != "" {
+		return refTracker.baseURL + "/" + b.schemaFilename(structInfo)
+	}
+	return b.schemaFilename(structInfo)
+}
+
+// schemaFilename returns the filename a struct's schema is written to.
+func (b *Builder) schemaFilename(structInfo parser.StructInfo) string {
+	if structInfo.File != "" {
+		return CustomFilename(structInfo.File, b.extension)
+	}
+	return SchemaFilename(structInfo.Name, b.extension)
 }
 
 // SetStructMap configures the builder with struct information for per-struct inline support.
@@ -47,70 +258,251 @@ func (b *Builder) BuildSchema(structInfo parser.StructInfo, refTracker *RefTrack
 		}
 		// Mark the current struct as in-progress to detect self-references
 		inlineCtx.InProgress[structInfo.Name] = true
+
+		// Hoist frequently used types into $defs instead of inlining every use
+		if structInfo.Inline && b.hoistThreshold > 0 {
+			inlineCtx.Hoisted = make(map[string]bool)
+			inlineCtx.Defs = make(jsonschema.Definitions)
+			counts := make(map[string]int)
+			b.countStructRefs(structInfo, counts, map[string]bool{structInfo.Name: true})
+			for name, count := range counts {
+				if count >= b.hoistThreshold {
+					inlineCtx.Hoisted[name] = true
+				}
+			}
+		}
 	}
 
 	schema := &jsonschema.Schema{
-		Version: JSONSchemaDraft,
-		Title:   structInfo.Name,
+		Version: b.schemaURI,
+		Title:   structInfo.TypeName(),
 		Type:    "object",
 	}
 
+	if b.humanizeTitles {
+		schema.Title = HumanizeName(structInfo.TypeName())
+	}
+
 	// Set $id if base URL is provided (uses lowercase to match output filename)
 	if b.schemaID != "" {
-		schema.ID = jsonschema.ID(b.schemaID + "/" + strings.ToLower(structInfo.Name) + ".schema.json")
+		schema.ID = jsonschema.ID(b.schemaID + "/" + b.schemaFilename(structInfo))
+	}
+	// A +schema:id marker overrides the computed $id
+	if structInfo.ID != "" {
+		schema.ID = jsonschema.ID(structInfo.ID)
 	}
 
 	// Set description from doc comment
 	if structInfo.Doc != "" {
 		schema.Description = structInfo.Doc
 	}
+	// The first sentence of the doc comment replaces the struct name as title
+	if b.titleFromComment && structInfo.Doc != "" {
+		schema.Title, schema.Description = splitFirstSentence(structInfo.Doc)
+	}
 
-	// Build properties
-	properties := jsonschema.NewProperties()
-	var required []string
+	if b.stamp != "" {
+		setExtra(schema, "x-generator", b.stamp)
+	}
 
-	for _, field := range structInfo.Fields {
-		// Build field schema
-		fieldSchema, err := BuildFieldSchema(field, refTracker, inlineCtx)
-		if err != nil {
-			return nil, err
-		}
+	// Provenance goes to $comment rather than the description
+	if b.schemaComment && structInfo.FilePath != "" {
+		schema.Comments = fmt.Sprintf("Generated by json-schema-gen from %s. DO NOT EDIT.", filepath.ToSlash(structInfo.FilePath))
+	}
 
-		// Apply validator constraints
-		isRequired := b.mapper.ApplyValidation(fieldSchema, field)
-		if isRequired && !field.OmitEmpty {
-			required = append(required, field.PropertyName)
-		}
+	// Custom marshalers serialize independently of their fields
+	if fallback := b.marshalerSchema(parser.TypeInfo{Kind: parser.TypeKindStruct, Name: structInfo.Name}); fallback != nil {
+		schema.Type = fallback.Type
+		return schema, nil
+	}
 
-		// Add to properties
-		properties.Set(field.PropertyName, fieldSchema)
+	// Named slices and maps (type Users []User) are arrays or objects of their element type
+	if structInfo.Collection != nil {
+		return b.buildCollectionSchema(schema, structInfo, refTracker, inlineCtx)
+	}
+
+	// Build properties
+	fields, bases := b.splitEmbedded(structInfo.Fields, map[string]bool{structInfo.Name: true})
+	b.warnUnmappedFields(structInfo.Name, fields)
+	properties, required, err := b.buildProperties(fields, refTracker, inlineCtx)
+	if err != nil {
+		return nil, err
 	}
 
 	schema.Properties = properties
 	if len(required) > 0 {
 		schema.Required = required
 	}
+	schema.DependentRequired = structInfo.DependentRequired
+
+	if err := b.applyAdditionalProperties(schema, structInfo, refTracker, inlineCtx); err != nil {
+		return nil, err
+	}
+
+	// Embedded structs in ref mode extend the schema via allOf
+	if err := b.composeBases(schema, bases, refTracker, inlineCtx); err != nil {
+		return nil, err
+	}
+
+	// $defs is a map; encoding/json writes its keys sorted, so the output
+	// does not depend on traversal order
+	if inlineCtx != nil && len(inlineCtx.Defs) > 0 {
+		schema.Definitions = inlineCtx.Defs
+	}
+
+	// With defs refs, the root schema bundles everything it references
+	if b.refStyle == RefStyleDefs && refTracker != nil && !refTracker.bundling {
+		if err := b.bundleDefs(schema, structInfo.Name, refTracker); err != nil {
+			return nil, err
+		}
+	}
+
+	if b.baseRef != "" {
+		composeAllOf(schema, &jsonschema.Schema{Ref: b.baseRef})
+	}
+
+	return schema, nil
+}
+
+// buildCollectionSchema builds the root schema of a named collection, keeping
+// the root keywords ($schema, $id, title, description, x-generator) of root.
+func (b *Builder) buildCollectionSchema(root *jsonschema.Schema, structInfo parser.StructInfo, refTracker *RefTracker, inlineCtx *InlineContext) (*jsonschema.Schema, error) {
+	schema, err := b.BuildFieldSchema(collectionField(structInfo), refTracker, inlineCtx)
+	if err != nil {
+		return nil, err
+	}
+	applyTypeNullability(schema, *structInfo.Collection, b.openAPIVersion)
 
+	schema.Version = root.Version
+	schema.ID = root.ID
+	schema.Title = root.Title
+	schema.Description = root.Description
+	for key, value := range root.Extras {
+		setExtra(schema, key, value)
+	}
+	if inlineCtx != nil && len(inlineCtx.Defs) > 0 {
+		schema.Definitions = inlineCtx.Defs
+	}
 	return schema, nil
 }
 
+// collectionField returns a named collection as a field of its type, so it
+// is built like a struct field of that type.
+func collectionField(structInfo parser.StructInfo) parser.FieldInfo {
+	return parser.FieldInfo{
+		Name: structInfo.Name,
+		Type: *structInfo.Collection,
+		Doc:  structInfo.Doc,
+	}
+}
+
+// countStructRefs counts how often each struct would be inlined when fully
+// expanding structInfo. Every use counts, including uses nested in other inlined structs.
+func (b *Builder) countStructRefs(structInfo parser.StructInfo, counts map[string]int, inProgress map[string]bool) {
+	fields, bases := b.splitEmbedded(structInfo.Fields, map[string]bool{structInfo.Name: true})
+	if structInfo.Collection != nil {
+		fields = append(fields, collectionField(structInfo))
+	}
+	for _, field := range append(fields, bases...) {
+		if schemaTag, ok := field.Tags["schema"]; ok {
+			if opts := parseSchemaTag(schemaTag); opts.Type != "" || opts.Ref != "" {
+				continue // Type derivation is skipped for overridden fields
+			}
+		}
+
+		names := field.AnyOf
+		if name, ok := structRefName(field.Type); ok {
+			names = append([]string{name}, names...)
+		}
+		for _, name := range names {
+			refStruct, ok := b.structMap[name]
+			if !ok || inProgress[name] {
+				continue
+			}
+
+			counts[name]++
+			inProgress[name] = true
+			b.countStructRefs(refStruct, counts, inProgress)
+			delete(inProgress, name)
+		}
+	}
+}
+
+// structRefName returns the name of the local struct a type refers to,
+// looking through pointers, slices, arrays and map values.
+func structRefName(typeInfo parser.TypeInfo) (string, bool) {
+	underlying := typeInfo.Underlying()
+	switch underlying.Kind {
+	case parser.TypeKindStruct:
+		if underlying.IsExported && underlying.PackageName == "" {
+			return underlying.Name, true
+		}
+	case parser.TypeKindSlice, parser.TypeKindArray, parser.TypeKindMap:
+		if underlying.ElemType != nil {
+			return structRefName(*underlying.ElemType)
+		}
+	}
+	return "", false
+}
+
 // BuildSchemaWithRefs creates a JSON Schema and returns all referenced types.
 // Note: This method is used for dependency tracking, so it always collects refs
 // regardless of per-struct inline settings.
 func (b *Builder) BuildSchemaWithRefs(structInfo parser.StructInfo) (*jsonschema.Schema, []string, error) {
-	refTracker := NewRefTracker()
+	refTracker := b.NewRefTracker()
 	// Create a modified structInfo without inline to collect all refs
 	nonInlineInfo := structInfo
 	nonInlineInfo.Inline = false
-	schema, err := b.BuildSchema(nonInlineInfo, refTracker)
+	schema, err := b.forParameters(structInfo).BuildSchema(nonInlineInfo, refTracker)
 	if err != nil {
 		return nil, nil, err
 	}
 	return schema, refTracker.GetRefs(), nil
 }
 
+// isRuneField returns true for fields of type rune or *rune.
+func isRuneField(field parser.FieldInfo) bool {
+	underlying := field.Type.Underlying()
+	return underlying.Kind == parser.TypeKindPrimitive && underlying.Name == "rune"
+}
+
+// isReadOnly reports whether a field matches the read-only field patterns.
+func (b *Builder) isReadOnly(field parser.FieldInfo) bool {
+	if b.readOnlyFields == nil {
+		return false
+	}
+	return b.readOnlyFields.MatchString(field.PropertyName) || b.readOnlyFields.MatchString(field.Name)
+}
+
+// applyNonEmpty requires at least one item or property for slice and map
+// schemas, unless a validator already set a lower bound.
+func applyNonEmpty(schema *jsonschema.Schema, typeInfo parser.TypeInfo) {
+	one := uint64(1)
+	switch typeInfo.Underlying().Kind {
+	case parser.TypeKindSlice:
+		if hasType(schema, "array") && schema.MinItems == nil {
+			schema.MinItems = &one
+		}
+	case parser.TypeKindMap:
+		if hasType(schema, "object") && schema.MinProperties == nil {
+			schema.MinProperties = &one
+		}
+	}
+}
+
 // buildInlineSchema creates an inline schema for a struct (used in inline mode).
+// The result is a sub-schema (inlined property or $defs entry), so it never
+// carries $schema or $id; those belong to the root schema only.
 func (b *Builder) buildInlineSchema(structInfo parser.StructInfo, inlineCtx *InlineContext) (*jsonschema.Schema, error) {
+	if structInfo.Collection != nil {
+		schema, err := b.BuildFieldSchema(collectionField(structInfo), nil, inlineCtx)
+		if err != nil {
+			return nil, err
+		}
+		applyTypeNullability(schema, *structInfo.Collection, b.openAPIVersion)
+		return schema, nil
+	}
+
 	schema := &jsonschema.Schema{
 		Type: "object",
 	}
@@ -120,31 +512,144 @@ func (b *Builder) buildInlineSchema(structInfo parser.StructInfo, inlineCtx *Inl
 		schema.Description = structInfo.Doc
 	}
 
-	// Build properties
+	// Build properties with inline context
+	fields, bases := b.splitEmbedded(structInfo.Fields, map[string]bool{structInfo.Name: true})
+	b.warnUnmappedFields(structInfo.Name, fields)
+	properties, required, err := b.buildProperties(fields, nil, inlineCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	schema.Properties = properties
+	if len(required) > 0 {
+		schema.Required = required
+	}
+	schema.DependentRequired = structInfo.DependentRequired
+
+	if err := b.applyAdditionalProperties(schema, structInfo, nil, inlineCtx); err != nil {
+		return nil, err
+	}
+
+	if err := b.composeBases(schema, bases, nil, inlineCtx); err != nil {
+		return nil, err
+	}
+
+	return schema, nil
+}
+
+// applyAdditionalProperties allows extra properties matching the value schema
+// of a struct's catch-all map field (+schema:additional-properties=Field).
+func (b *Builder) applyAdditionalProperties(schema *jsonschema.Schema, structInfo parser.StructInfo, refTracker *RefTracker, inlineCtx *InlineContext) error {
+	field := structInfo.AdditionalProperties
+	if field == nil {
+		return nil
+	}
+
+	underlying := field.Type.Underlying()
+	if underlying.Kind != parser.TypeKindMap || underlying.ElemType == nil {
+		b.warnOnce("struct %s: additional-properties field %s is not a map", structInfo.Name, field.Name)
+		return nil
+	}
+
+	valueSchema, err := b.buildElemSchema(*underlying.ElemType, refTracker, inlineCtx)
+	if err != nil {
+		return err
+	}
+	schema.AdditionalProperties = valueSchema
+	return nil
+}
+
+// buildProperties builds the property schemas for a list of fields and
+// returns the names of required properties.
+func (b *Builder) buildProperties(fields []parser.FieldInfo, refTracker *RefTracker, inlineCtx *InlineContext) (*orderedmap.OrderedMap[string, *jsonschema.Schema], []string, error) {
 	properties := jsonschema.NewProperties()
 	var required []string
 
-	for _, field := range structInfo.Fields {
-		// Build field schema with inline context
-		fieldSchema, err := BuildFieldSchema(field, nil, inlineCtx)
+	for _, field := range fields {
+		// Build field schema
+		fieldSchema, err := b.BuildFieldSchema(field, refTracker, inlineCtx)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
+		}
+
+		if b.isReadOnly(field) {
+			fieldSchema.ReadOnly = true
+		}
+
+		// Form generators label inputs with the title
+		if b.autoFieldTitles && fieldSchema.Title == "" {
+			fieldSchema.Title = humanizeProperty(field.PropertyName)
+		}
+
+		// A lone rune holds a single character
+		if b.runeAsString && fieldSchema.Type == "integer" && isRuneField(field) {
+			length := uint64(1)
+			fieldSchema.Type = "string"
+			fieldSchema.MinLength = &length
+			fieldSchema.MaxLength = &length
 		}
 
 		// Apply validator constraints
 		isRequired := b.mapper.ApplyValidation(fieldSchema, field)
-		if isRequired && !field.OmitEmpty {
+		if (isRequired || b.requiredUnlessOmitEmpty && !field.Type.IsPointer) && !field.OmitEmpty && !b.noRequired {
 			required = append(required, field.PropertyName)
 		}
 
+		// Document the first allowed value as an example
+		if b.examplesFromEnum && len(fieldSchema.Enum) > 0 && len(fieldSchema.Examples) == 0 {
+			fieldSchema.Examples = []any{fieldSchema.Enum[0]}
+		}
+
+		// Required rejects empty slices and maps, not just missing ones
+		if isRequired && b.requiredNonEmpty {
+			applyNonEmpty(fieldSchema, field.Type)
+		}
+		// Required on a pointer only checks for nil, so "" passes there
+		if isRequired && b.requiredStrings && !field.Type.IsPointer && hasType(fieldSchema, "string") && fieldSchema.MinLength == nil {
+			one := uint64(1)
+			fieldSchema.MinLength = &one
+		}
+
+		// Fill in integer bounds implied by the Go type but not set by validators
+		applyIntegerBounds(fieldSchema, field.Type, b.intrinsicBounds)
+
+		// Nullable types such as sql.NullString also accept null
+		applyTypeNullability(fieldSchema, field.Type, b.openAPIVersion)
+
+		// Pointer fields accept null when targeting OpenAPI or with nullable pointers
+		if field.Type.IsPointer {
+			switch {
+			case b.openAPIVersion != "":
+				applyNullable(fieldSchema, b.openAPIVersion)
+			case b.nullablePointers:
+				makeTypeNullable(fieldSchema)
+			}
+		}
+
+		// Format overrides, local anchors, ids, array contains, negations and x- extensions from the schema tag
+		if schemaTag, ok := field.Tags["schema"]; ok {
+			opts := parseSchemaTag(schemaTag)
+			if opts.Format != "" {
+				fieldSchema.Format = opts.Format
+			}
+			if opts.Nullable {
+				if b.openAPIVersion != "" {
+					applyNullable(fieldSchema, b.openAPIVersion)
+				} else {
+					makeTypeNullable(fieldSchema)
+				}
+			}
+			b.applySchemaID(fieldSchema, opts.ID, field.Name)
+			b.applyContains(fieldSchema, opts, field.Name)
+			b.applyNot(fieldSchema, opts, field.Name)
+			for key, value := range opts.Extensions {
+				setExtra(fieldSchema, key, value)
+			}
+		}
+
 		// Add to properties
 		properties.Set(field.PropertyName, fieldSchema)
 	}
 
-	schema.Properties = properties
-	if len(required) > 0 {
-		schema.Required = required
-	}
-
-	return schema, nil
+	return properties, required, nil
 }
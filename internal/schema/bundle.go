@@ -0,0 +1,59 @@
+package schema
+
+import (
+	"slices"
+
+	"github.com/invopop/jsonschema"
+)
+
+// bundleDefs adds the schemas of all types the root references, directly or
+// through other bundled types, to its $defs. Types that are not known to the
+// builder are left as dangling refs, like missing files in file mode.
+func (b *Builder) bundleDefs(root *jsonschema.Schema, rootName string, refTracker *RefTracker) error {
+	if root.Definitions == nil {
+		root.Definitions = make(jsonschema.Definitions)
+	}
+
+	refTracker.bundling = true
+	defer func() { refTracker.bundling = false }()
+
+	for {
+		added := false
+		refs := refTracker.GetRefs()
+		slices.Sort(refs)
+		for _, name := range refs {
+			if _, exists := root.Definitions[name]; exists || name == rootName {
+				continue
+			}
+			structInfo, ok := b.structMap[name]
+			if !ok {
+				continue
+			}
+
+			def, err := b.BuildSchema(structInfo, refTracker)
+			if err != nil {
+				return err
+			}
+			root.Definitions[name] = asDefinition(def)
+			added = true
+		}
+		if !added {
+			break
+		}
+	}
+
+	if len(root.Definitions) == 0 {
+		root.Definitions = nil
+	}
+	return nil
+}
+
+// asDefinition strips the root keywords of a schema built as a $defs entry;
+// definitions are sub-schemas and the root keywords stay on the root.
+func asDefinition(def *jsonschema.Schema) *jsonschema.Schema {
+	def.Version = ""
+	def.ID = ""
+	def.Comments = ""
+	delete(def.Extras, "x-generator")
+	return def
+}
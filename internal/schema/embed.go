@@ -0,0 +1,110 @@
+package schema
+
+import (
+	"github.com/invopop/jsonschema"
+	"github.com/ron96g/json-schema-gen/internal/parser"
+)
+
+const (
+	// EmbedModeFlatten promotes the fields of embedded structs into the parent,
+	// matching how encoding/json marshals them.
+	EmbedModeFlatten = "flatten"
+	// EmbedModeRef composes the parent as allOf [{$ref: embedded}, {own fields}].
+	EmbedModeRef = "ref"
+)
+
+// embeddedStructName returns the name of the local struct an embedded field
+// without a name tag refers to. Tagged embeds are regular properties.
+func embeddedStructName(field parser.FieldInfo) (string, bool) {
+	if !field.Promoted || field.Type.Underlying().Kind != parser.TypeKindStruct {
+		return "", false
+	}
+	return structRefName(field.Type)
+}
+
+// splitEmbedded resolves embedded structs of a field list. In flatten mode
+// their fields are promoted in place (recursively), with fields declared
+// directly on a struct shadowing promoted fields of the same property name.
+// In ref mode the embedded fields are returned as bases for allOf composition,
+// except for embeds tagged inline or squash, which are always flattened.
+// Embedded structs that are not known yet stay regular fields.
+func (b *Builder) splitEmbedded(fields []parser.FieldInfo, visiting map[string]bool) (own, bases []parser.FieldInfo) {
+	declared := make(map[string]bool)
+	for _, field := range fields {
+		if _, ok := embeddedStructName(field); !ok {
+			declared[field.PropertyName] = true
+		}
+	}
+
+	promoted := make(map[string]bool)
+	for _, field := range fields {
+		name, ok := embeddedStructName(field)
+		if !ok {
+			own = append(own, field)
+			continue
+		}
+		if b.embedMode == EmbedModeRef && !field.Squash {
+			bases = append(bases, field)
+			continue
+		}
+
+		embedded, ok := b.structMap[name]
+		if !ok || visiting[name] {
+			own = append(own, field)
+			continue
+		}
+
+		visiting[name] = true
+		inner, _ := b.splitEmbedded(embedded.Fields, visiting)
+		delete(visiting, name)
+		for _, innerField := range inner {
+			if declared[innerField.PropertyName] || promoted[innerField.PropertyName] {
+				continue
+			}
+			promoted[innerField.PropertyName] = true
+			own = append(own, innerField)
+		}
+	}
+	return own, bases
+}
+
+// composeBases turns schema into allOf [{bases...}, {own constraints}] for
+// embedded structs in ref mode. Embedded schemas are refs, or inline schemas
+// when the parent is inlined.
+func (b *Builder) composeBases(schema *jsonschema.Schema, bases []parser.FieldInfo, refTracker *RefTracker, inlineCtx *InlineContext) error {
+	if len(bases) == 0 {
+		return nil
+	}
+
+	members := make([]*jsonschema.Schema, 0, len(bases))
+	for _, field := range bases {
+		field.Doc = "" // The embed comment describes the parent, not the base
+		baseSchema, err := b.BuildFieldSchema(field, refTracker, inlineCtx)
+		if err != nil {
+			return err
+		}
+		members = append(members, baseSchema)
+	}
+	composeAllOf(schema, members...)
+	return nil
+}
+
+// composeAllOf moves the object constraints of a schema into the last member
+// of its allOf and prepends the given members. Other keywords ($schema, $id,
+// title, description, $defs) stay in place.
+func composeAllOf(schema *jsonschema.Schema, members ...*jsonschema.Schema) {
+	if len(schema.AllOf) == 0 {
+		own := &jsonschema.Schema{
+			Type:                 schema.Type,
+			Properties:           schema.Properties,
+			Required:             schema.Required,
+			AdditionalProperties: schema.AdditionalProperties,
+		}
+		schema.Type = ""
+		schema.Properties = nil
+		schema.Required = nil
+		schema.AdditionalProperties = nil
+		schema.AllOf = []*jsonschema.Schema{own}
+	}
+	schema.AllOf = append(members, schema.AllOf...)
+}
@@ -0,0 +1,76 @@
+package schema
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/invopop/jsonschema"
+	"github.com/ron96g/json-schema-gen/internal/parser"
+)
+
+// SetEnums configures the typed constants of named types, keyed by type name.
+func (b *Builder) SetEnums(enums map[string][]parser.EnumValue) {
+	b.enums = enums
+}
+
+// applyEnum restricts a local named type's schema to its constant values.
+// With documented enums, values are emitted as a oneOf of const+description
+// entries if any value has a comment; otherwise a plain enum is used.
+func (b *Builder) applyEnum(schema *jsonschema.Schema, typeInfo parser.TypeInfo) {
+	if typeInfo.Kind != parser.TypeKindAlias || typeInfo.PackageName != "" {
+		return
+	}
+	values := b.enums[typeInfo.Name]
+	if len(values) == 0 {
+		return
+	}
+
+	if b.documentedEnums && hasEnumDocs(values) {
+		for _, v := range values {
+			schema.OneOf = append(schema.OneOf, &jsonschema.Schema{
+				Const:       v.Value,
+				Description: v.Doc,
+			})
+		}
+		return
+	}
+
+	names := make([]string, 0, len(values))
+	labels := make([]string, 0, len(values))
+	for _, v := range values {
+		schema.Enum = append(schema.Enum, v.Value)
+		names = append(names, v.Name)
+		labels = append(labels, enumLabel(typeInfo.Name, v.Name))
+	}
+	// Code generators use the constant names for the enum members
+	if b.enumVarnames {
+		setExtra(schema, "x-enum-varnames", names)
+	}
+	// UI generators display labels instead of raw values
+	if b.enumNames {
+		setExtra(schema, "x-enumNames", labels)
+	}
+}
+
+// enumLabel derives a human label from a constant name, dropping the type
+// name prefix (StatusInProgress of Status becomes "In Progress").
+func enumLabel(typeName, constName string) string {
+	name := constName
+	if rest, ok := strings.CutPrefix(constName, typeName); ok && rest != "" {
+		if r, _ := utf8.DecodeRuneInString(rest); unicode.IsUpper(r) || unicode.IsDigit(r) {
+			name = rest
+		}
+	}
+	return HumanizeName(name)
+}
+
+// hasEnumDocs reports whether any enum value has a description.
+func hasEnumDocs(values []parser.EnumValue) bool {
+	for _, v := range values {
+		if v.Doc != "" {
+			return true
+		}
+	}
+	return false
+}
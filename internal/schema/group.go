@@ -0,0 +1,45 @@
+package schema
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/invopop/jsonschema"
+	"github.com/ron96g/json-schema-gen/internal/parser"
+)
+
+// BuildGroup builds a schema bundling the given structs of one source file
+// under $defs, along with all types they reference. The group has no root
+// type of its own; consumers point at #/$defs/<Type>.
+func (b *Builder) BuildGroup(name, sourceFile string, structs []parser.StructInfo) (*jsonschema.Schema, error) {
+	schema := &jsonschema.Schema{
+		Version:     b.schemaURI,
+		Definitions: make(jsonschema.Definitions),
+	}
+	if b.schemaID != "" {
+		schema.ID = jsonschema.ID(b.schemaID + "/" + SchemaFilename(name, b.extension))
+	}
+	if b.stamp != "" {
+		setExtra(schema, "x-generator", b.stamp)
+	}
+	if b.schemaComment && sourceFile != "" {
+		schema.Comments = fmt.Sprintf("Generated by json-schema-gen from %s. DO NOT EDIT.", filepath.ToSlash(sourceFile))
+	}
+
+	// Members are bundled into the group rather than into each other
+	refTracker := b.NewRefTracker()
+	refTracker.bundling = true
+	for _, structInfo := range structs {
+		def, err := b.BuildSchema(structInfo, refTracker)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", structInfo.Name, err)
+		}
+		schema.Definitions[structInfo.Name] = asDefinition(def)
+	}
+	refTracker.bundling = false
+
+	if err := b.bundleDefs(schema, "", refTracker); err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
@@ -0,0 +1,29 @@
+package schema
+
+import (
+	"github.com/invopop/jsonschema"
+	"github.com/ron96g/json-schema-gen/internal/parser"
+)
+
+// TypeHandler returns a custom schema for a type, or nil to fall through to
+// the built-in mapping. It must return a new schema on every call, since
+// validators and descriptions are applied to the returned schema.
+type TypeHandler func(typeInfo parser.TypeInfo) *jsonschema.Schema
+
+// RegisterTypeHandler adds a handler consulted for field and collection
+// element types before any built-in type mapping (schema tag overrides still
+// take precedence). Handlers run in registration order; the first non-nil schema wins.
+func (b *Builder) RegisterTypeHandler(handler TypeHandler) {
+	b.typeHandlers = append(b.typeHandlers, handler)
+}
+
+// customSchema returns the schema of the first registered handler accepting
+// the type, or nil if none does.
+func (b *Builder) customSchema(typeInfo parser.TypeInfo) *jsonschema.Schema {
+	for _, handler := range b.typeHandlers {
+		if schema := handler(typeInfo); schema != nil {
+			return schema
+		}
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/invopop/jsonschema"
+	"github.com/ron96g/json-schema-gen/internal/parser"
+)
+
+// TestRegisterTypeHandler checks that a registered handler replaces the
+// default schema of the types it accepts, including collection elements,
+// and that validators still apply to the returned schema.
+func TestRegisterTypeHandler(t *testing.T) {
+	decimal := parser.TypeInfo{Kind: parser.TypeKindStruct, Name: "decimal.Decimal", IsExported: true}
+	structInfo := parser.StructInfo{
+		Name: "Invoice",
+		Fields: []parser.FieldInfo{
+			{Name: "Total", PropertyName: "total", Type: decimal, Tags: map[string]string{"validate": "required,max=20"}},
+			{Name: "Lines", PropertyName: "lines", Type: parser.TypeInfo{Kind: parser.TypeKindSlice, Name: "[]decimal.Decimal", ElemType: &decimal}},
+			{Name: "Note", PropertyName: "note", Type: parser.TypeInfo{Kind: parser.TypeKindPrimitive, Name: "string"}},
+		},
+	}
+
+	b := NewBuilder(Config{})
+	b.RegisterTypeHandler(func(typeInfo parser.TypeInfo) *jsonschema.Schema {
+		if typeInfo.Name != "decimal.Decimal" {
+			return nil
+		}
+		return &jsonschema.Schema{Type: "string", Pattern: `^-?[0-9]+(\.[0-9]+)?$`}
+	})
+	schema, err := b.BuildSchema(structInfo, b.NewRefTracker())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]*jsonschema.Schema{
+		"total": {Type: "string", Pattern: `^-?[0-9]+(\.[0-9]+)?$`, MaxLength: uintPtr(20)},
+		"lines": {Type: "array", Items: &jsonschema.Schema{Type: "string", Pattern: `^-?[0-9]+(\.[0-9]+)?$`}},
+		"note":  {Type: "string"},
+	}
+	for name, wantSchema := range want {
+		got, ok := schema.Properties.Get(name)
+		if !ok {
+			t.Errorf("property %s missing", name)
+			continue
+		}
+		if got, want := marshalSchema(t, got), marshalSchema(t, wantSchema); got != want {
+			t.Errorf("%s = %s, want %s", name, got, want)
+		}
+	}
+	if len(schema.Required) != 1 || schema.Required[0] != "total" {
+		t.Errorf("required = %v, want [total]", schema.Required)
+	}
+}
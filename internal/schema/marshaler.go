@@ -0,0 +1,49 @@
+package schema
+
+import (
+	"github.com/invopop/jsonschema"
+	"github.com/ron96g/json-schema-gen/internal/parser"
+)
+
+// MarshalerAsAny emits an unconstrained schema for json.Marshaler types.
+const MarshalerAsAny = "any"
+
+// SetMarshalers configures the types known to implement custom marshaling.
+func (b *Builder) SetMarshalers(marshalers map[string]parser.MarshalerKind) {
+	b.marshalers = marshalers
+}
+
+// SetWarnFunc configures the function used to report non-fatal warnings.
+func (b *Builder) SetWarnFunc(warnf func(format string, args ...any)) {
+	b.warnf = warnf
+	b.mapper.warnf = b.warnOnce
+}
+
+// marshalerSchema returns the schema for local types implementing json.Marshaler
+// (configurable fallback) or encoding.TextMarshaler (always a string), or nil
+// if the type uses regular struct/type introspection.
+func (b *Builder) marshalerSchema(typeInfo parser.TypeInfo) *jsonschema.Schema {
+	if typeInfo.PackageName != "" || (typeInfo.Kind != parser.TypeKindStruct && typeInfo.Kind != parser.TypeKindAlias) {
+		return nil
+	}
+
+	switch b.marshalers[typeInfo.Name] {
+	case parser.MarshalerText:
+		return &jsonschema.Schema{Type: "string"}
+	case parser.MarshalerJSON:
+	default:
+		return nil
+	}
+
+	if !b.warnedMarshalers[typeInfo.Name] {
+		b.warnedMarshalers[typeInfo.Name] = true
+		if b.warnf != nil {
+			b.warnf("type %s implements json.Marshaler; using %q fallback schema", typeInfo.Name, b.marshalerAs)
+		}
+	}
+
+	if b.marshalerAs == MarshalerAsAny {
+		return &jsonschema.Schema{}
+	}
+	return &jsonschema.Schema{Type: b.marshalerAs}
+}
@@ -0,0 +1,62 @@
+package schema
+
+import (
+	"strings"
+	"unicode"
+)
+
+// HumanizeName converts a Go identifier into space-separated words.
+// Acronyms are kept together, e.g. "HTTPServer" becomes "HTTP Server",
+// including plural acronyms ("UserIDs" becomes "User IDs").
+func HumanizeName(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			// A trailing s ending the name or followed by the next word pluralizes the acronym
+			plural := nextLower && runes[i+1] == 's' && (i+2 == len(runes) || unicode.IsUpper(runes[i+2]))
+			// Split at lower->Upper ("serviceConfig") and at the end of an acronym ("HTTPServer")
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower && !plural) {
+				b.WriteRune(' ')
+			}
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+// splitFirstSentence splits a doc comment at the first period followed by
+// whitespace into its first sentence (without the period) and the rest.
+func splitFirstSentence(doc string) (first, rest string) {
+	for i, r := range doc {
+		if r != '.' {
+			continue
+		}
+		next := doc[i+1:]
+		if next == "" {
+			break
+		}
+		if unicode.IsSpace([]rune(next)[0]) {
+			return doc[:i], strings.TrimSpace(next)
+		}
+	}
+	return strings.TrimSuffix(doc, "."), ""
+}
+
+// humanizeProperty converts a property name in any case into capitalized
+// words, e.g. "zip_code" and "zipCode" both become "Zip Code".
+func humanizeProperty(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return r == '_' || r == '-' || r == '.' || unicode.IsSpace(r)
+	})
+	for i, word := range words {
+		runes := []rune(HumanizeName(word))
+		runes[0] = unicode.ToUpper(runes[0])
+		words[i] = string(runes)
+	}
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,87 @@
+package schema
+
+import (
+	"github.com/invopop/jsonschema"
+	"github.com/ron96g/json-schema-gen/internal/parser"
+)
+
+const (
+	// OpenAPIVersion30 emits the OpenAPI 3.0 `nullable: true` keyword.
+	OpenAPIVersion30 = "3.0"
+	// OpenAPIVersion31 emits a JSON Schema type array including "null".
+	OpenAPIVersion31 = "3.1"
+)
+
+// applyNullable marks a schema as accepting null using the representation
+// required by the given OpenAPI version.
+func applyNullable(schema *jsonschema.Schema, openAPIVersion string) {
+	switch openAPIVersion {
+	case OpenAPIVersion30:
+		// Siblings of $ref are ignored in OpenAPI 3.0, so wrap the ref in allOf
+		if schema.Ref != "" {
+			schema.AllOf = []*jsonschema.Schema{{Ref: schema.Ref}}
+			schema.Ref = ""
+		}
+		setExtra(schema, "nullable", true)
+
+	case OpenAPIVersion31:
+		makeTypeNullable(schema)
+	}
+}
+
+// applyTypeNullability marks schemas of nullable types (e.g., sql.NullString)
+// as accepting null, including slice items and map values.
+func applyTypeNullability(schema *jsonschema.Schema, typeInfo parser.TypeInfo, openAPIVersion string) {
+	if schema == nil {
+		return
+	}
+
+	underlying := typeInfo.Underlying()
+	switch {
+	case underlying.Nullable:
+		if openAPIVersion == OpenAPIVersion30 {
+			applyNullable(schema, openAPIVersion)
+		} else {
+			makeTypeNullable(schema)
+		}
+
+	case underlying.Kind == parser.TypeKindSlice || underlying.Kind == parser.TypeKindArray:
+		if underlying.ElemType != nil {
+			applyTypeNullability(schema.Items, *underlying.ElemType, openAPIVersion)
+		}
+
+	case underlying.Kind == parser.TypeKindMap:
+		if underlying.ElemType != nil {
+			applyTypeNullability(schema.AdditionalProperties, *underlying.ElemType, openAPIVersion)
+		}
+	}
+}
+
+// makeTypeNullable adds "null" to the schema's type using a type array.
+// Refs are wrapped in anyOf since a $ref cannot carry a type array.
+func makeTypeNullable(schema *jsonschema.Schema) {
+	switch {
+	case schema.Type != "":
+		setExtra(schema, "type", []string{schema.Type, "null"})
+		schema.Type = ""
+		// A null value must also pass the enum check
+		if len(schema.Enum) > 0 {
+			schema.Enum = append(schema.Enum, nil)
+		}
+
+	case schema.Ref != "":
+		schema.AnyOf = []*jsonschema.Schema{
+			{Ref: schema.Ref},
+			{Type: "null"},
+		}
+		schema.Ref = ""
+	}
+}
+
+// setExtra sets a non-standard keyword on a schema.
+func setExtra(schema *jsonschema.Schema, key string, value any) {
+	if schema.Extras == nil {
+		schema.Extras = make(map[string]any)
+	}
+	schema.Extras[key] = value
+}
@@ -0,0 +1,83 @@
+package schema
+
+import (
+	"fmt"
+
+	"github.com/invopop/jsonschema"
+	"github.com/ron96g/json-schema-gen/internal/parser"
+)
+
+// Parameter is an OpenAPI parameter object.
+type Parameter struct {
+	Name        string             `json:"name"`
+	In          string             `json:"in"`
+	Description string             `json:"description,omitempty"`
+	Required    bool               `json:"required,omitempty"`
+	Schema      *jsonschema.Schema `json:"schema"`
+}
+
+// parameterLocations are the valid values of the `in` tag.
+var parameterLocations = map[string]bool{"query": true, "path": true, "header": true, "cookie": true}
+
+// BuildParameters builds OpenAPI parameters from the properties of a
+// +schema:parameters struct (e.g., a query binding struct). The `in` tag of
+// a field sets its location (query if absent); path parameters are always
+// required. Field descriptions move from the schema to the parameter.
+// Parameters are flat, so embedded structs are always flattened into them.
+func (b *Builder) BuildParameters(structInfo parser.StructInfo, refTracker *RefTracker) ([]Parameter, error) {
+	b = b.forParameters(structInfo)
+	schema, err := b.BuildSchema(structInfo, refTracker)
+	if err != nil {
+		return nil, err
+	}
+	if schema.Properties == nil {
+		return nil, nil
+	}
+
+	fields, _ := b.splitEmbedded(structInfo.Fields, map[string]bool{structInfo.Name: true})
+	locations := make(map[string]string, len(fields))
+	for _, field := range fields {
+		in := field.Tags["in"]
+		if in == "" {
+			in = "query"
+		}
+		if !parameterLocations[in] {
+			return nil, fmt.Errorf("field %s: invalid in %q: must be one of query, path, header, cookie", field.Name, in)
+		}
+		locations[field.PropertyName] = in
+	}
+
+	required := make(map[string]bool, len(schema.Required))
+	for _, name := range schema.Required {
+		required[name] = true
+	}
+
+	var params []Parameter
+	for pair := schema.Properties.Oldest(); pair != nil; pair = pair.Next() {
+		in := locations[pair.Key]
+		if in == "" {
+			in = "query"
+		}
+		param := Parameter{
+			Name:        pair.Key,
+			In:          in,
+			Description: pair.Value.Description,
+			Required:    required[pair.Key] || in == "path",
+			Schema:      pair.Value,
+		}
+		pair.Value.Description = ""
+		params = append(params, param)
+	}
+	return params, nil
+}
+
+// forParameters returns a builder flattening embedded structs if structInfo
+// is a +schema:parameters struct, and b otherwise.
+func (b *Builder) forParameters(structInfo parser.StructInfo) *Builder {
+	if !structInfo.Parameters || b.embedMode == EmbedModeFlatten {
+		return b
+	}
+	flat := *b
+	flat.embedMode = EmbedModeFlatten
+	return &flat
+}
@@ -2,19 +2,63 @@ package schema
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 )
 
+// DefaultExtension is the file suffix of generated schema files.
+const DefaultExtension = ".schema.json"
+
+const (
+	// RefStyleFile references other schemas by relative file name (user.schema.json).
+	RefStyleFile = "file"
+	// RefStyleDefs bundles referenced schemas into the root's $defs (#/$defs/User).
+	RefStyleDefs = "defs"
+	// RefStyleID references other schemas by their absolute $id URL.
+	RefStyleID = "id"
+)
+
+// SchemaFilename returns the schema filename for a type using the given
+// extension (DefaultExtension if empty).
+func SchemaFilename(typeName, extension string) string {
+	if extension == "" {
+		extension = DefaultExtension
+	}
+	return strings.ToLower(typeName) + extension
+}
+
+// CustomFilename returns the filename of a +schema:file marker for the given
+// extension. Names ending in ".json" take the ".yaml" suffix for YAML output.
+func CustomFilename(name, extension string) string {
+	if strings.HasSuffix(extension, ".yaml") {
+		if base, ok := strings.CutSuffix(name, ".json"); ok {
+			return base + ".yaml"
+		}
+	}
+	return name
+}
+
 // RefTracker tracks $ref references to other schemas.
 type RefTracker struct {
-	refs     map[string]bool // Set of referenced type names
-	basePath string          // Base path for relative references
+	refs      map[string]bool // Set of referenced type names
+	basePath  string          // Base path for relative references
+	style     string          // Ref style (RefStyleFile, RefStyleDefs or RefStyleID)
+	extension string          // Schema file extension used in ref paths
+	baseURL   string          // Base URL for absolute refs in RefStyleID
+	bundling  bool            // Building $defs entries of a root schema
 }
 
 // NewRefTracker creates a new RefTracker.
-func NewRefTracker() *RefTracker {
+// Ref paths depend on the style: relative file references using the given
+// file extension (DefaultExtension if empty), $defs pointers, or absolute
+// URLs under baseURL.
+func NewRefTracker(style, extension, baseURL string) *RefTracker {
 	return &RefTracker{
-		refs: make(map[string]bool),
+		refs:      make(map[string]bool),
+		style:     style,
+		extension: extension,
+		baseURL:   strings.TrimSuffix(baseURL, "/"),
 	}
 }
 
@@ -23,12 +67,14 @@ func (rt *RefTracker) AddRef(typeName string) {
 	rt.refs[typeName] = true
 }
 
-// GetRefs returns all recorded references.
+// GetRefs returns all recorded references, sorted so that callers do not
+// depend on map iteration order.
 func (rt *RefTracker) GetRefs() []string {
 	refs := make([]string, 0, len(rt.refs))
 	for ref := range rt.refs {
 		refs = append(refs, ref)
 	}
+	slices.Sort(refs)
 	return refs
 }
 
@@ -39,8 +85,14 @@ func (rt *RefTracker) HasRef(typeName string) bool {
 
 // GetRefPath returns the $ref path for a type name.
 func (rt *RefTracker) GetRefPath(typeName string) string {
+	switch {
+	case rt.style == RefStyleDefs:
+		return "#/$defs/" + typeName
+	case rt.style == RefStyleID && rt.baseURL != "":
+		return rt.baseURL + "/" + SchemaFilename(typeName, rt.extension)
+	}
 	// Use relative file reference
-	return fmt.Sprintf("%s.schema.json", strings.ToLower(typeName))
+	return SchemaFilename(typeName, rt.extension)
 }
 
 // Clear removes all tracked references.
@@ -155,7 +207,8 @@ func (dg *DependencyGraph) DetectCircular() ([]string, bool) {
 		return false
 	}
 
-	for typeName := range dg.dependencies {
+	// Visit in sorted order so the same cycle is reported on every run
+	for _, typeName := range slices.Sorted(maps.Keys(dg.dependencies)) {
 		if visit(typeName, nil) {
 			return cycle, true
 		}
@@ -0,0 +1,38 @@
+package schema
+
+import (
+	"slices"
+
+	"github.com/invopop/jsonschema"
+)
+
+// RegistryName is the name the registry schema is written under (index.schema.json).
+const RegistryName = "index"
+
+// BuildRegistry builds a registry schema whose properties are $refs to the
+// schemas of the given types, keyed by type name. Refs follow the ref style,
+// except that defs refs point to the files, as root schemas are still
+// written as files.
+func (b *Builder) BuildRegistry(typeNames []string) *jsonschema.Schema {
+	style := b.refStyle
+	if style == RefStyleDefs {
+		style = RefStyleFile
+	}
+	refTracker := NewRefTracker(style, b.extension, b.schemaID)
+
+	schema := &jsonschema.Schema{
+		Version:     b.schemaURI,
+		Title:       "Registry",
+		Description: "Registry of all generated schemas.",
+		Type:        "object",
+		Properties:  jsonschema.NewProperties(),
+	}
+	if b.schemaID != "" {
+		schema.ID = jsonschema.ID(b.schemaID + "/" + SchemaFilename(RegistryName, b.extension))
+	}
+
+	for _, name := range slices.Sorted(slices.Values(typeNames)) {
+		schema.Properties.Set(name, &jsonschema.Schema{Ref: b.refPath(refTracker, name)})
+	}
+	return schema
+}
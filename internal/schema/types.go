@@ -2,7 +2,11 @@
 package schema
 
 import (
+	"encoding/json"
 	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/invopop/jsonschema"
@@ -16,6 +20,8 @@ type InlineContext struct {
 	StructMap    map[string]parser.StructInfo // Map of struct names to their info
 	InProgress   map[string]bool              // Tracks types being built (circular ref detection)
 	Builder      *Builder                     // Reference to builder for recursive calls
+	Hoisted      map[string]bool              // Types emitted once under $defs instead of inlined
+	Defs         jsonschema.Definitions       // Hoisted definitions collected for the root schema
 }
 
 // GoTypeToJSONSchema converts a Go TypeInfo to JSON Schema type and format.
@@ -78,26 +84,167 @@ func primitiveToSchema(name string) (string, string) {
 	}
 }
 
+// intrinsicIntegerRange returns the representable range of a sized Go integer type.
+// Platform-dependent types (int, uint, uintptr) have no fixed range.
+func intrinsicIntegerRange(name string) (minimum, maximum string, ok bool) {
+	switch name {
+	case "int8":
+		return "-128", "127", true
+	case "int16":
+		return "-32768", "32767", true
+	case "int32", "rune":
+		return "-2147483648", "2147483647", true
+	case "int64":
+		return "-9223372036854775808", "9223372036854775807", true
+	case "uint8", "byte":
+		return "0", "255", true
+	case "uint16":
+		return "0", "65535", true
+	case "uint32":
+		return "0", "4294967295", true
+	case "uint64":
+		return "0", "18446744073709551615", true
+	default:
+		return "", "", false
+	}
+}
+
+// isUnsignedInteger returns true for Go unsigned integer types.
+func isUnsignedInteger(name string) bool {
+	switch name {
+	case "uint", "uint8", "uint16", "uint32", "uint64", "uintptr", "byte":
+		return true
+	default:
+		return false
+	}
+}
+
+// applyIntegerBounds sets bounds derived from the Go integer type on integer
+// schemas, including slice items and map values. Unsigned types always get a
+// minimum of 0; with intrinsic set, sized types get their full range.
+// Bounds already set by validators are kept.
+func applyIntegerBounds(schema *jsonschema.Schema, typeInfo parser.TypeInfo, intrinsic bool) {
+	if schema == nil {
+		return
+	}
+
+	underlying := typeInfo.Underlying().ResolveUnderlying()
+
+	switch underlying.Kind {
+	case parser.TypeKindPrimitive:
+		if schema.Type != "integer" {
+			return
+		}
+		minimum, maximum, ok := intrinsicIntegerRange(underlying.Name)
+		if !intrinsic || !ok {
+			if !isUnsignedInteger(underlying.Name) {
+				return
+			}
+			minimum, maximum = "0", ""
+		}
+		if schema.Minimum == "" && schema.ExclusiveMinimum == "" {
+			schema.Minimum = json.Number(minimum)
+		}
+		if maximum != "" && schema.Maximum == "" && schema.ExclusiveMaximum == "" {
+			schema.Maximum = json.Number(maximum)
+		}
+
+	case parser.TypeKindSlice, parser.TypeKindArray:
+		if underlying.ElemType != nil {
+			applyIntegerBounds(schema.Items, *underlying.ElemType, intrinsic)
+		}
+
+	case parser.TypeKindMap:
+		if underlying.ElemType != nil {
+			applyIntegerBounds(schema.AdditionalProperties, *underlying.ElemType, intrinsic)
+		}
+	}
+}
+
+// applyKeyNames constrains the keys of a map with an enum or integer key type
+// via propertyNames. JSON object keys are strings, so enum values and
+// integers are matched by their text. Enum values keep their declaration
+// order, so the output is stable across runs.
+func (b *Builder) applyKeyNames(schema *jsonschema.Schema, mapType parser.TypeInfo) {
+	if !b.deterministicMaps || mapType.KeyType == nil {
+		return
+	}
+	key := *mapType.KeyType
+	names := &jsonschema.Schema{Type: "string"}
+
+	switch values := b.enums[key.Name]; {
+	case key.Kind == parser.TypeKindAlias && key.PackageName == "" && len(values) > 0:
+		for _, v := range values {
+			names.Enum = append(names.Enum, fmt.Sprint(v.Value))
+		}
+
+	case key.Kind != parser.TypeKindPrimitive && key.Kind != parser.TypeKindAlias:
+		return
+
+	case isUnsignedInteger(key.ResolveUnderlying().Name):
+		names.Pattern = "^(?:0|[1-9][0-9]*)$"
+
+	default:
+		if schemaType, _ := primitiveToSchema(key.ResolveUnderlying().Name); schemaType != "integer" {
+			return
+		}
+		names.Pattern = "^(?:0|-?[1-9][0-9]*)$"
+	}
+	schema.PropertyNames = names
+}
+
 // BuildFieldSchema creates a JSON Schema for a field's type.
 // If inlineCtx is provided and enabled, struct references are inlined instead of using $ref.
-func BuildFieldSchema(field parser.FieldInfo, refTracker *RefTracker, inlineCtx *InlineContext) (*jsonschema.Schema, error) {
+func (b *Builder) BuildFieldSchema(field parser.FieldInfo, refTracker *RefTracker, inlineCtx *InlineContext) (*jsonschema.Schema, error) {
 	schema := &jsonschema.Schema{}
 
-	// Check for schema tag override (e.g., schema:"type=string")
+	// Check for schema tag overrides (e.g., schema:"type=string" or schema:"ref=https://...")
 	if schemaTag, ok := field.Tags["schema"]; ok {
-		if overrideType := parseSchemaTypeOverride(schemaTag); overrideType != "" {
-			schema.Type = overrideType
+		opts := parseSchemaTag(schemaTag)
+		if opts.Ref != "" {
+			schema.Ref = opts.Ref
 			// Add description from doc comment
 			if field.Doc != "" {
 				schema.Description = field.Doc
 			}
 			return schema, nil
 		}
+		if opts.Type != "" {
+			schema.Type = opts.Type
+			// Add description from doc comment
+			if field.Doc != "" {
+				schema.Description = field.Doc
+			}
+			return schema, nil
+		}
+		if len(opts.Tuple) > 0 && b.validTuple(opts.Tuple, field.Name) {
+			schema = tupleSchema(opts.Tuple)
+			if field.Doc != "" {
+				schema.Description = field.Doc
+			}
+			return schema, nil
+		}
 	}
 
 	// Handle based on type kind
 	underlying := field.Type.Underlying()
 
+	// Registered type handlers take precedence over the built-in mapping
+	if custom := b.customSchema(underlying); custom != nil {
+		if field.Doc != "" {
+			custom.Description = field.Doc
+		}
+		return custom, nil
+	}
+
+	// Custom marshalers serialize independently of their Go shape
+	if fallback := b.marshalerSchema(underlying); fallback != nil {
+		if field.Doc != "" {
+			fallback.Description = field.Doc
+		}
+		return fallback, nil
+	}
+
 	switch underlying.Kind {
 	case parser.TypeKindPrimitive:
 		schemaType, format := primitiveToSchema(underlying.Name)
@@ -121,11 +268,12 @@ func BuildFieldSchema(field parser.FieldInfo, refTracker *RefTracker, inlineCtx
 		if format != "" {
 			schema.Format = format
 		}
+		b.applyEnum(schema, underlying)
 
 	case parser.TypeKindSlice, parser.TypeKindArray:
 		schema.Type = "array"
 		if underlying.ElemType != nil {
-			elemSchema, err := buildElemSchema(*underlying.ElemType, refTracker, inlineCtx)
+			elemSchema, err := b.buildElemSchema(*underlying.ElemType, refTracker, inlineCtx)
 			if err != nil {
 				return nil, err
 			}
@@ -135,30 +283,36 @@ func BuildFieldSchema(field parser.FieldInfo, refTracker *RefTracker, inlineCtx
 	case parser.TypeKindMap:
 		schema.Type = "object"
 		if underlying.ElemType != nil {
-			valueSchema, err := buildElemSchema(*underlying.ElemType, refTracker, inlineCtx)
+			valueSchema, err := b.buildElemSchema(*underlying.ElemType, refTracker, inlineCtx)
 			if err != nil {
 				return nil, err
 			}
 			schema.AdditionalProperties = valueSchema
 		}
+		b.applyKeyNames(schema, underlying)
 
 	case parser.TypeKindStruct:
 		// Reference to another struct
 		if underlying.IsExported && underlying.PackageName == "" {
+			// Single-field wrappers are replaced by their field's schema
+			flattened, err := b.flattenWrapper(underlying.Name, refTracker, inlineCtx)
+			if err != nil {
+				return nil, err
+			}
+
 			// Determine if we should inline this specific struct reference
 			shouldInline := shouldInlineStruct(inlineCtx)
 
-			if shouldInline {
+			if flattened != nil {
+				schema = flattened
+			} else if shouldInline {
 				inlinedSchema, err := inlineStructSchema(underlying.Name, inlineCtx)
 				if err != nil {
 					return nil, err
 				}
 				if inlinedSchema != nil {
-					// Copy relevant fields from inlined schema
-					schema.Type = inlinedSchema.Type
-					schema.Properties = inlinedSchema.Properties
-					schema.Required = inlinedSchema.Required
-					schema.Description = inlinedSchema.Description
+					// Use the inlined schema (or $defs reference) for this field
+					schema = inlinedSchema
 				} else {
 					// Referenced type not found, treat as object
 					schema.Type = "object"
@@ -167,7 +321,7 @@ func BuildFieldSchema(field parser.FieldInfo, refTracker *RefTracker, inlineCtx
 				// Use $ref
 				if refTracker != nil {
 					refTracker.AddRef(underlying.Name)
-					schema.Ref = refTracker.GetRefPath(underlying.Name)
+					schema.Ref = b.refPath(refTracker, underlying.Name)
 				} else {
 					schema.Type = "object"
 				}
@@ -186,6 +340,13 @@ func BuildFieldSchema(field parser.FieldInfo, refTracker *RefTracker, inlineCtx
 		schema.Type = "string"
 	}
 
+	// A +schema:anyof marker lists the types an interface value may match
+	if len(field.AnyOf) > 0 {
+		if err := b.applyAnyOf(schema, field, refTracker, inlineCtx); err != nil {
+			return nil, err
+		}
+	}
+
 	// Add description from doc comment
 	if field.Doc != "" {
 		schema.Description = field.Doc
@@ -205,6 +366,27 @@ func shouldInlineStruct(inlineCtx *InlineContext) bool {
 	return inlineCtx.ParentInline
 }
 
+// flattenWrapper returns the schema of a referenced single-field struct's only
+// field when flattening is enabled, or nil if the struct is not a wrapper.
+// The wrapper's own schema (if generated) is unaffected.
+func (b *Builder) flattenWrapper(name string, refTracker *RefTracker, inlineCtx *InlineContext) (*jsonschema.Schema, error) {
+	if !b.flattenSingleField || inlineCtx == nil || inlineCtx.InProgress[name] {
+		return nil, nil
+	}
+	wrapper, ok := b.structMap[name]
+	if !ok || len(wrapper.Fields) != 1 {
+		return nil, nil
+	}
+
+	inlineCtx.InProgress[name] = true
+	properties, _, err := b.buildProperties(wrapper.Fields, refTracker, inlineCtx)
+	delete(inlineCtx.InProgress, name)
+	if err != nil {
+		return nil, err
+	}
+	return properties.Oldest().Value, nil
+}
+
 // inlineStructSchema creates an inline schema for a referenced struct.
 func inlineStructSchema(name string, inlineCtx *InlineContext) (*jsonschema.Schema, error) {
 	structInfo, ok := inlineCtx.StructMap[name]
@@ -218,6 +400,20 @@ func inlineStructSchema(name string, inlineCtx *InlineContext) (*jsonschema.Sche
 		return nil, fmt.Errorf("circular reference detected: %s", name)
 	}
 
+	// Hoisted types are built once into $defs and referenced from there
+	if inlineCtx.Hoisted[name] {
+		if _, exists := inlineCtx.Defs[name]; !exists {
+			inlineCtx.InProgress[name] = true
+			defSchema, err := inlineCtx.Builder.buildInlineSchema(structInfo, inlineCtx)
+			if err != nil {
+				return nil, err
+			}
+			delete(inlineCtx.InProgress, name)
+			inlineCtx.Defs[name] = defSchema
+		}
+		return &jsonschema.Schema{Ref: "#/$defs/" + name}, nil
+	}
+
 	// Mark as in-progress
 	inlineCtx.InProgress[name] = true
 
@@ -234,9 +430,16 @@ func inlineStructSchema(name string, inlineCtx *InlineContext) (*jsonschema.Sche
 }
 
 // buildElemSchema creates a schema for collection element types.
-func buildElemSchema(typeInfo parser.TypeInfo, refTracker *RefTracker, inlineCtx *InlineContext) (*jsonschema.Schema, error) {
+func (b *Builder) buildElemSchema(typeInfo parser.TypeInfo, refTracker *RefTracker, inlineCtx *InlineContext) (*jsonschema.Schema, error) {
 	underlying := typeInfo.Underlying()
 
+	if custom := b.customSchema(underlying); custom != nil {
+		return custom, nil
+	}
+	if fallback := b.marshalerSchema(underlying); fallback != nil {
+		return fallback, nil
+	}
+
 	switch underlying.Kind {
 	case parser.TypeKindPrimitive:
 		schemaType, format := primitiveToSchema(underlying.Name)
@@ -258,10 +461,16 @@ func buildElemSchema(typeInfo parser.TypeInfo, refTracker *RefTracker, inlineCtx
 		if format != "" {
 			schema.Format = format
 		}
+		b.applyEnum(schema, underlying)
 		return schema, nil
 
 	case parser.TypeKindStruct:
 		if underlying.IsExported && underlying.PackageName == "" {
+			flattened, err := b.flattenWrapper(underlying.Name, refTracker, inlineCtx)
+			if err != nil || flattened != nil {
+				return flattened, err
+			}
+
 			// Determine if we should inline this specific struct reference
 			shouldInline := shouldInlineStruct(inlineCtx)
 
@@ -279,7 +488,7 @@ func buildElemSchema(typeInfo parser.TypeInfo, refTracker *RefTracker, inlineCtx
 			// Use $ref
 			if refTracker != nil {
 				refTracker.AddRef(underlying.Name)
-				return &jsonschema.Schema{Ref: refTracker.GetRefPath(underlying.Name)}, nil
+				return &jsonschema.Schema{Ref: b.refPath(refTracker, underlying.Name)}, nil
 			}
 			return &jsonschema.Schema{Type: "object"}, nil
 		}
@@ -288,7 +497,7 @@ func buildElemSchema(typeInfo parser.TypeInfo, refTracker *RefTracker, inlineCtx
 	case parser.TypeKindSlice, parser.TypeKindArray:
 		schema := &jsonschema.Schema{Type: "array"}
 		if underlying.ElemType != nil {
-			items, err := buildElemSchema(*underlying.ElemType, refTracker, inlineCtx)
+			items, err := b.buildElemSchema(*underlying.ElemType, refTracker, inlineCtx)
 			if err != nil {
 				return nil, err
 			}
@@ -299,12 +508,13 @@ func buildElemSchema(typeInfo parser.TypeInfo, refTracker *RefTracker, inlineCtx
 	case parser.TypeKindMap:
 		schema := &jsonschema.Schema{Type: "object"}
 		if underlying.ElemType != nil {
-			additionalProps, err := buildElemSchema(*underlying.ElemType, refTracker, inlineCtx)
+			additionalProps, err := b.buildElemSchema(*underlying.ElemType, refTracker, inlineCtx)
 			if err != nil {
 				return nil, err
 			}
 			schema.AdditionalProperties = additionalProps
 		}
+		b.applyKeyNames(schema, underlying)
 		return schema, nil
 
 	default:
@@ -312,14 +522,335 @@ func buildElemSchema(typeInfo parser.TypeInfo, refTracker *RefTracker, inlineCtx
 	}
 }
 
-// parseSchemaTypeOverride extracts the type override from a schema tag.
-// Supports format: schema:"type=string" or schema:"type=integer"
-func parseSchemaTypeOverride(schemaTag string) string {
-	for _, part := range strings.Split(schemaTag, ",") {
+// applyAnyOf restricts the interface value of a field, or the interface
+// elements of a slice or map field, to anyOf the +schema:anyof types.
+// Unlike oneOf, a value may match several of them.
+func (b *Builder) applyAnyOf(schema *jsonschema.Schema, field parser.FieldInfo, refTracker *RefTracker, inlineCtx *InlineContext) error {
+	target := interfaceSchema(schema, field.Type)
+	if target == nil {
+		b.warnOnce("field %s: +schema:anyof only applies to interface types", field.Name)
+		return nil
+	}
+
+	for _, name := range field.AnyOf {
+		member, err := b.buildElemSchema(parser.TypeInfo{Kind: parser.TypeKindStruct, Name: name, IsExported: true}, refTracker, inlineCtx)
+		if err != nil {
+			return err
+		}
+		target.AnyOf = append(target.AnyOf, member)
+	}
+	return nil
+}
+
+// interfaceSchema returns the schema of the interface value of a type,
+// looking through pointers, slices, arrays and map values.
+func interfaceSchema(schema *jsonschema.Schema, typeInfo parser.TypeInfo) *jsonschema.Schema {
+	underlying := typeInfo.Underlying()
+	switch {
+	case underlying.Kind == parser.TypeKindInterface:
+		return schema
+	case underlying.ElemType == nil:
+		return nil
+	case (underlying.Kind == parser.TypeKindSlice || underlying.Kind == parser.TypeKindArray) && schema.Items != nil:
+		return interfaceSchema(schema.Items, *underlying.ElemType)
+	case underlying.Kind == parser.TypeKindMap && schema.AdditionalProperties != nil:
+		return interfaceSchema(schema.AdditionalProperties, *underlying.ElemType)
+	}
+	return nil
+}
+
+// schemaTagOptions holds the options of a field's schema tag.
+type schemaTagOptions struct {
+	Type   string   // Type override (type=string)
+	Format string   // Format override, taking precedence over validators (format=idn-email)
+	Ref    string   // External schema reference (ref=https://example.com/money.schema.json)
+	Tuple  []string // Per-position item types (tuple=number,number)
+	ID     string   // Field $anchor (id=#emailField) or $id (id=https://...)
+
+	Nullable bool // Also allow null, regardless of pointer detection (nullable)
+
+	// Contains is the schema array items must match (contains=string or a JSON schema),
+	// MinContains and MaxContains bound the number of matches
+	Contains    string
+	MinContains string
+	MaxContains string
+
+	// Not forbids values matching a single keyword (not=pattern:^tmp-),
+	// NotEnum forbids the listed values (notEnum=root,admin)
+	Not     string
+	NotEnum []string
+
+	// Extensions holds x- vendor extensions (x-ui-widget=select)
+	Extensions map[string]any
+}
+
+// jsonTypes are the JSON Schema primitive type names.
+var jsonTypes = map[string]bool{
+	"string": true, "number": true, "integer": true, "boolean": true,
+	"object": true, "array": true, "null": true,
+}
+
+// parseSchemaTag parses a schema tag into its options.
+// Supports format: schema:"type=string" or schema:"ref=https://example.com/money.schema.json"
+// A tuple spec continues over the following comma-separated type names without "=": schema:"tuple=number,number"
+// A notEnum spec continues over the following values without "=": schema:"notEnum=root,admin"
+// Vendor extensions (schema:"x-ui-widget=select") may hold JSON values, whose commas are kept.
+func parseSchemaTag(schemaTag string) schemaTagOptions {
+	var opts schemaTagOptions
+	inTuple, inNotEnum := false, false
+	for _, part := range splitSchemaTag(schemaTag) {
 		part = strings.TrimSpace(part)
-		if strings.HasPrefix(part, "type=") {
-			return strings.TrimPrefix(part, "type=")
+		switch {
+		case inTuple && part != "nullable" && !strings.Contains(part, "="):
+			opts.Tuple = append(opts.Tuple, part)
+			continue
+		case inNotEnum && part != "nullable" && !strings.Contains(part, "="):
+			opts.NotEnum = append(opts.NotEnum, part)
+			continue
+		case strings.HasPrefix(part, "type="):
+			opts.Type = strings.TrimPrefix(part, "type=")
+		case strings.HasPrefix(part, "format="):
+			opts.Format = strings.TrimPrefix(part, "format=")
+		case strings.HasPrefix(part, "ref="):
+			opts.Ref = strings.TrimPrefix(part, "ref=")
+		case strings.HasPrefix(part, "id="):
+			opts.ID = strings.TrimPrefix(part, "id=")
+		case part == "nullable":
+			opts.Nullable = true
+		case strings.HasPrefix(part, "contains="):
+			opts.Contains = strings.TrimPrefix(part, "contains=")
+		case strings.HasPrefix(part, "minContains="):
+			opts.MinContains = strings.TrimPrefix(part, "minContains=")
+		case strings.HasPrefix(part, "maxContains="):
+			opts.MaxContains = strings.TrimPrefix(part, "maxContains=")
+		case strings.HasPrefix(part, "x-") && strings.Contains(part, "="):
+			key, value, _ := strings.Cut(part, "=")
+			if opts.Extensions == nil {
+				opts.Extensions = make(map[string]any)
+			}
+			opts.Extensions[key] = parseExtensionValue(value)
+		case strings.HasPrefix(part, "not="):
+			opts.Not = strings.TrimPrefix(part, "not=")
+		case strings.HasPrefix(part, "notEnum="):
+			opts.NotEnum = []string{strings.TrimPrefix(part, "notEnum=")}
+			inTuple, inNotEnum = false, true
+			continue
+		case strings.HasPrefix(part, "tuple="):
+			opts.Tuple = []string{strings.TrimPrefix(part, "tuple=")}
+			inTuple, inNotEnum = true, false
+			continue
+		}
+		inTuple, inNotEnum = false, false
+	}
+	return opts
+}
+
+// splitSchemaTag splits a schema tag on commas outside of JSON brackets and strings.
+func splitSchemaTag(tag string) []string {
+	var parts []string
+	var current strings.Builder
+	depth := 0
+	inString, escaped := false, false
+
+	for _, ch := range tag {
+		switch {
+		case escaped:
+			escaped = false
+		case inString && ch == '\\':
+			escaped = true
+		case ch == '"':
+			inString = !inString
+		case inString:
+		case ch == '[' || ch == '{':
+			depth++
+		case ch == ']' || ch == '}':
+			depth--
+		case ch == ',' && depth == 0:
+			parts = append(parts, current.String())
+			current.Reset()
+			continue
+		}
+		current.WriteRune(ch)
+	}
+
+	if current.Len() > 0 {
+		parts = append(parts, current.String())
+	}
+	return parts
+}
+
+// parseExtensionValue decodes JSON-looking extension values (objects, arrays,
+// numbers, booleans, null, quoted strings); anything else is kept as a string.
+func parseExtensionValue(value string) any {
+	var decoded any
+	if err := json.Unmarshal([]byte(value), &decoded); err == nil {
+		return decoded
+	}
+	return value
+}
+
+// anchorPattern matches valid $anchor names (plain-name fragments).
+var anchorPattern = regexp.MustCompile(`^[A-Za-z_][-A-Za-z0-9._]*$`)
+
+// applySchemaID sets a field's $anchor from a "#name" id, or its $id otherwise.
+// Invalid anchors are reported and skipped.
+func (b *Builder) applySchemaID(schema *jsonschema.Schema, id, fieldName string) {
+	switch {
+	case id == "":
+		return
+	case strings.HasPrefix(id, "#"):
+		anchor := strings.TrimPrefix(id, "#")
+		if !anchorPattern.MatchString(anchor) {
+			b.warnOnce("field %s: invalid anchor %q: must start with a letter or underscore followed by letters, digits, '-', '_' or '.'", fieldName, id)
+			return
+		}
+		schema.Anchor = anchor
+	default:
+		schema.ID = jsonschema.ID(id)
+	}
+}
+
+// applyContains sets contains, minContains and maxContains from the schema tag.
+// They only apply to arrays; invalid or misplaced options are reported and skipped.
+func (b *Builder) applyContains(schema *jsonschema.Schema, opts schemaTagOptions, fieldName string) {
+	if opts.Contains == "" && opts.MinContains == "" && opts.MaxContains == "" {
+		return
+	}
+	if !hasType(schema, "array") {
+		b.warnOnce("field %s: contains, minContains and maxContains only apply to arrays", fieldName)
+		return
+	}
+	if opts.Contains == "" {
+		b.warnOnce("field %s: minContains and maxContains require contains", fieldName)
+		return
+	}
+
+	contains, err := containsSchema(opts.Contains)
+	if err != nil {
+		b.warnOnce("field %s: invalid contains %q: %v", fieldName, opts.Contains, err)
+		return
+	}
+	minContains, err := parseCount(opts.MinContains)
+	if err != nil {
+		b.warnOnce("field %s: invalid minContains %q: must be a non-negative integer", fieldName, opts.MinContains)
+		return
+	}
+	maxContains, err := parseCount(opts.MaxContains)
+	if err != nil {
+		b.warnOnce("field %s: invalid maxContains %q: must be a non-negative integer", fieldName, opts.MaxContains)
+		return
+	}
+
+	schema.Contains = contains
+	schema.MinContains = minContains
+	schema.MaxContains = maxContains
+}
+
+// applyNot forbids values from the schema tag's not and notEnum options via
+// a not subschema. Numeric fields get numeric notEnum values; invalid options
+// are reported and skipped.
+func (b *Builder) applyNot(schema *jsonschema.Schema, opts schemaTagOptions, fieldName string) {
+	if opts.Not != "" {
+		not, err := notSchema(opts.Not)
+		if err != nil {
+			b.warnOnce("field %s: invalid not %q: %v", fieldName, opts.Not, err)
+		} else {
+			addNot(schema, not)
+		}
+	}
+
+	if len(opts.NotEnum) > 0 {
+		values := make([]any, len(opts.NotEnum))
+		for i, v := range opts.NotEnum {
+			values[i] = v
+			if hasType(schema, "integer") || hasType(schema, "number") {
+				if _, err := strconv.ParseFloat(v, 64); err != nil {
+					b.warnOnce("field %s: invalid notEnum value %q: must be a number", fieldName, v)
+					return
+				}
+				values[i] = json.Number(v)
+			}
 		}
+		addNot(schema, &jsonschema.Schema{Enum: values})
+	}
+}
+
+// notSchema parses a not option of the form keyword:value, where keyword is
+// pattern, format or const.
+func notSchema(value string) (*jsonschema.Schema, error) {
+	keyword, arg, ok := strings.Cut(value, ":")
+	if !ok || arg == "" {
+		return nil, fmt.Errorf("must be pattern:REGEX, format:NAME or const:VALUE")
+	}
+	switch keyword {
+	case "pattern":
+		if _, err := regexp.Compile(arg); err != nil {
+			return nil, err
+		}
+		return &jsonschema.Schema{Pattern: arg}, nil
+	case "format":
+		return &jsonschema.Schema{Format: arg}, nil
+	case "const":
+		return &jsonschema.Schema{Const: parseExtensionValue(arg)}, nil
+	}
+	return nil, fmt.Errorf("unknown keyword %q: must be pattern, format or const", keyword)
+}
+
+// containsSchema parses a contains option: a JSON type name or a JSON schema object.
+func containsSchema(value string) (*jsonschema.Schema, error) {
+	if jsonTypes[value] {
+		return &jsonschema.Schema{Type: value}, nil
+	}
+	if !strings.HasPrefix(value, "{") {
+		return nil, fmt.Errorf("must be a JSON type or a JSON schema object")
+	}
+	schema := &jsonschema.Schema{}
+	if err := json.Unmarshal([]byte(value), schema); err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+// parseCount parses an optional non-negative count (nil if empty).
+func parseCount(value string) (*uint64, error) {
+	if value == "" {
+		return nil, nil
+	}
+	n, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
+
+// hasType reports whether a schema's type, or type array, includes t.
+func hasType(schema *jsonschema.Schema, t string) bool {
+	if schema.Type == t {
+		return true
+	}
+	types, _ := schema.Extras["type"].([]string)
+	return slices.Contains(types, t)
+}
+
+// validTuple reports whether every tuple position names a JSON type.
+// Unknown types are reported and the tuple is skipped.
+func (b *Builder) validTuple(types []string, fieldName string) bool {
+	for _, t := range types {
+		if !jsonTypes[t] {
+			b.warnOnce("field %s: invalid tuple type %q: must be one of string, number, integer, boolean, object, array or null", fieldName, t)
+			return false
+		}
+	}
+	return true
+}
+
+// tupleSchema creates a draft 2020-12 tuple schema with one item schema per
+// position and no additional items.
+func tupleSchema(types []string) *jsonschema.Schema {
+	schema := &jsonschema.Schema{Type: "array"}
+	for _, t := range types {
+		schema.PrefixItems = append(schema.PrefixItems, &jsonschema.Schema{Type: t})
 	}
-	return ""
+	schema.Items = jsonschema.FalseSchema
+	return schema
 }
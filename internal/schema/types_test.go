@@ -0,0 +1,48 @@
+package schema
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ron96g/json-schema-gen/internal/parser"
+)
+
+// TestTupleTypes checks that every tuple= position is validated and that an
+// unknown type skips the tuple with a warning.
+func TestTupleTypes(t *testing.T) {
+	tests := []struct {
+		tag      string
+		want     string
+		wantWarn string
+	}{
+		{tag: "tuple=number,string", want: `{"prefixItems":[{"type":"number"},{"type":"string"}],"items":false,"type":"array"}`},
+		{tag: "tuple=foo,number", want: `{"items":true,"type":"array"}`, wantWarn: `invalid tuple type "foo"`},
+		{tag: "tuple=number,strng", want: `{"items":true,"type":"array"}`, wantWarn: `invalid tuple type "strng"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			var warnings []string
+			b := NewBuilder(Config{})
+			b.SetWarnFunc(func(format string, args ...any) {
+				warnings = append(warnings, fmt.Sprintf(format, args...))
+			})
+			anyType := parser.TypeInfo{Kind: parser.TypeKindInterface, Name: "any"}
+			field := parser.FieldInfo{
+				Name: "Point",
+				Type: parser.TypeInfo{Kind: parser.TypeKindArray, Name: "[2]any", ElemType: &anyType},
+				Tags: map[string]string{"schema": tt.tag},
+			}
+			schema, err := b.BuildFieldSchema(field, b.NewRefTracker(), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := marshalSchema(t, schema); got != tt.want {
+				t.Errorf("schema = %s, want %s", got, tt.want)
+			}
+			if tt.wantWarn == "" && len(warnings) > 0 || tt.wantWarn != "" && (len(warnings) != 1 || !strings.Contains(warnings[0], tt.wantWarn)) {
+				t.Errorf("warnings = %q, want %q", warnings, tt.wantWarn)
+			}
+		})
+	}
+}
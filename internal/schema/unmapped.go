@@ -0,0 +1,45 @@
+package schema
+
+import "github.com/ron96g/json-schema-gen/internal/parser"
+
+// warnUnmappedFields reports fields whose external types are emitted as a bare
+// object, losing their field detail. Fields with a schema type override are
+// skipped since they never reach the type mapping.
+func (b *Builder) warnUnmappedFields(structName string, fields []parser.FieldInfo) {
+	if !b.warnUnmapped {
+		return
+	}
+
+	for _, field := range fields {
+		if schemaTag, ok := field.Tags["schema"]; ok {
+			if opts := parseSchemaTag(schemaTag); opts.Type != "" || len(opts.Tuple) > 0 {
+				continue
+			}
+		}
+		if name, ok := b.unmappedType(field.Type); ok {
+			b.warnOnce("struct %s: field %s: external type %s emitted as object", structName, field.PropertyName, name)
+		}
+	}
+}
+
+// unmappedType returns the name of the external struct type a field resolves
+// to, looking through pointers, slices, arrays and map values.
+func (b *Builder) unmappedType(typeInfo parser.TypeInfo) (string, bool) {
+	underlying := typeInfo.Underlying()
+	if b.customSchema(underlying) != nil {
+		return "", false
+	}
+
+	switch underlying.Kind {
+	case parser.TypeKindStruct:
+		if underlying.PackageName != "" {
+			return underlying.Name, true
+		}
+
+	case parser.TypeKindSlice, parser.TypeKindArray, parser.TypeKindMap:
+		if underlying.ElemType != nil {
+			return b.unmappedType(*underlying.ElemType)
+		}
+	}
+	return "", false
+}
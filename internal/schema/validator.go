@@ -1,224 +1,224 @@
 package schema
 
 import (
-	"encoding/json"
-	"regexp"
-	"strconv"
 	"strings"
 
 	"github.com/invopop/jsonschema"
 	"github.com/ron96g/json-schema-gen/internal/parser"
 )
 
+// DefaultValidationTag is the struct tag read for validator rules.
+const DefaultValidationTag = "validate"
+
 // ValidatorMapper maps go-playground/validator tags to JSON Schema constraints.
-type ValidatorMapper struct{}
+type ValidatorMapper struct {
+	tags  []string                         // Validation tags in priority order (e.g., validate, binding)
+	warnf func(format string, args ...any) // Reports rules skipped for invalid parameters
+}
 
 // NewValidatorMapper creates a new ValidatorMapper.
-func NewValidatorMapper() *ValidatorMapper {
-	return &ValidatorMapper{}
+// Rules are read from the given tags; earlier tags win when a rule appears in several.
+func NewValidatorMapper(tags ...string) *ValidatorMapper {
+	if len(tags) == 0 {
+		tags = []string{DefaultValidationTag}
+	}
+	return &ValidatorMapper{
+		tags: tags,
+	}
 }
 
 // ApplyValidation applies validator tag constraints to a JSON Schema.
 func (m *ValidatorMapper) ApplyValidation(schema *jsonschema.Schema, field parser.FieldInfo) (isRequired bool) {
-	validateTag, ok := field.Tags["validate"]
-	if !ok {
+	rules := m.fieldRules(field)
+	if len(rules) == 0 {
 		return false
 	}
 
-	rules := parseValidateTag(validateTag)
+	// Referenced schemas carry their own constraints, so only required-ness applies
+	if schema.Ref != "" {
+		return m.applyRulesToSchema(&jsonschema.Schema{}, rules, field.Name)
+	}
 
-	// Check for dive - split rules into array-level and item-level
-	diveIdx := -1
+	return m.applyRules(schema, rules, field.Name)
+}
+
+// applyRules applies rules to a schema. Rules after a dive apply to the
+// elements of arrays and maps (recursively for nested dives).
+func (m *ValidatorMapper) applyRules(schema *jsonschema.Schema, rules []ValidationRule, fieldName string) (isRequired bool) {
 	for i, rule := range rules {
 		if rule.Name == "dive" {
-			diveIdx = i
-			break
+			m.applyDive(schema, rules[i+1:], fieldName)
+			return m.applyRulesToSchema(schema, rules[:i], fieldName)
 		}
 	}
-
-	// If dive found and schema is array, apply item-level rules to items
-	if diveIdx >= 0 && schema.Type == "array" && schema.Items != nil {
-		// Apply rules after dive to items
-		itemRules := rules[diveIdx+1:]
-		m.applyRulesToSchema(schema.Items, itemRules)
-		// Apply rules before dive to array
-		rules = rules[:diveIdx]
-	}
-
-	isRequired = m.applyRulesToSchema(schema, rules)
-	return isRequired
+	return m.applyRulesToSchema(schema, rules, fieldName)
 }
 
-// applyRulesToSchema applies validation rules to a schema.
-func (m *ValidatorMapper) applyRulesToSchema(schema *jsonschema.Schema, rules []ValidationRule) (isRequired bool) {
-	isString := schema.Type == "string"
-	isNumeric := schema.Type == "integer" || schema.Type == "number"
-
-	for _, rule := range rules {
-		switch rule.Name {
-		case "required":
-			isRequired = true
-
-		case "omitempty":
-			// Not required
-
-		case "min":
-			if val, err := strconv.ParseFloat(rule.Param, 64); err == nil {
-				if isString {
-					minLen := uint64(val)
-					schema.MinLength = &minLen
-				} else if isNumeric {
-					schema.Minimum = json.Number(rule.Param)
-				}
-			}
-
-		case "max":
-			if val, err := strconv.ParseFloat(rule.Param, 64); err == nil {
-				if isString {
-					maxLen := uint64(val)
-					schema.MaxLength = &maxLen
-				} else if isNumeric {
-					schema.Maximum = json.Number(rule.Param)
-				}
-			}
-
-		case "len":
-			if val, err := strconv.ParseUint(rule.Param, 10, 64); err == nil {
-				if isString {
-					schema.MinLength = &val
-					schema.MaxLength = &val
-				}
+// applyDive applies element rules to array items or to map keys and values.
+// For maps, rules between keys and endkeys constrain the keys via propertyNames
+// and the remaining rules the values; a bare dive applies to the values.
+// Element rules on other schemas are ignored.
+func (m *ValidatorMapper) applyDive(schema *jsonschema.Schema, rules []ValidationRule, fieldName string) {
+	switch {
+	// Never apply to the shared false schema of a tuple
+	case schema.Type == "array" && schema.Items != nil && schema.Items != jsonschema.FalseSchema:
+		m.applyRules(schema.Items, rules, fieldName)
+
+	case schema.Type == "object" && schema.AdditionalProperties != nil:
+		keyRules, valueRules := splitKeyRules(rules)
+		if len(keyRules) > 0 {
+			// JSON object keys are always strings; key type constraints are kept
+			if schema.PropertyNames == nil {
+				schema.PropertyNames = &jsonschema.Schema{Type: "string"}
 			}
+			m.applyRules(schema.PropertyNames, keyRules, fieldName)
+		}
+		m.applyRules(schema.AdditionalProperties, valueRules, fieldName)
+	}
+}
 
-		case "gte":
-			if _, err := strconv.ParseFloat(rule.Param, 64); err == nil {
-				if isNumeric {
-					schema.Minimum = json.Number(rule.Param)
-				}
-			}
+// splitKeyRules splits map dive rules into key rules (between keys and endkeys)
+// and value rules. Without a leading keys rule, all rules apply to values.
+func splitKeyRules(rules []ValidationRule) (keyRules, valueRules []ValidationRule) {
+	if len(rules) == 0 || rules[0].Name != "keys" {
+		return nil, rules
+	}
+	for i, rule := range rules[1:] {
+		if rule.Name == "endkeys" {
+			return rules[1 : i+1], rules[i+2:]
+		}
+	}
+	return rules[1:], nil
+}
 
-		case "lte":
-			if _, err := strconv.ParseFloat(rule.Param, 64); err == nil {
-				if isNumeric {
-					schema.Maximum = json.Number(rule.Param)
-				}
-			}
+// fieldRules returns the merged validation rules of all configured tags.
+// Field-level and dive (item-level) rules are merged separately, and a rule
+// already defined by a higher priority tag is not overridden.
+func (m *ValidatorMapper) fieldRules(field parser.FieldInfo) []ValidationRule {
+	var fieldRules, itemRules []ValidationRule
+	definedField := make(map[string]bool) // Rules defined by higher priority tags
+	definedItem := make(map[string]bool)
+	hasDive := false
+
+	for _, tag := range m.tags {
+		tagValue, ok := field.Tags[tag]
+		if !ok {
+			continue
+		}
 
-		case "gt":
-			if _, err := strconv.ParseFloat(rule.Param, 64); err == nil {
-				if isNumeric {
-					schema.ExclusiveMinimum = json.Number(rule.Param)
+		var tagField, tagItem []ValidationRule
+		afterDive := false
+		for _, rule := range parseValidateTag(tagValue) {
+			switch {
+			case rule.Name == "dive" && !afterDive:
+				afterDive = true
+				hasDive = true
+			case afterDive:
+				if !definedItem[rule.Name] {
+					tagItem = append(tagItem, rule)
 				}
-			}
-
-		case "lt":
-			if _, err := strconv.ParseFloat(rule.Param, 64); err == nil {
-				if isNumeric {
-					schema.ExclusiveMaximum = json.Number(rule.Param)
+			default:
+				if !definedField[rule.Name] {
+					tagField = append(tagField, rule)
 				}
 			}
+		}
 
-		case "email":
-			schema.Format = "email"
-
-		case "url", "uri", "http_url":
-			schema.Format = "uri"
-
-		case "uuid", "uuid3", "uuid4", "uuid5":
-			schema.Format = "uuid"
-
-		case "ipv4":
-			schema.Format = "ipv4"
-
-		case "ipv6":
-			schema.Format = "ipv6"
-
-		case "ip":
-			// Could be either, use generic format
-			schema.Format = "ip"
-
-		case "datetime":
-			schema.Format = "date-time"
-
-		case "date":
-			// Custom format for date without time
-			schema.Format = "date"
-
-		case "oneof":
-			// Parse enum values
-			values := strings.Fields(rule.Param)
-			if len(values) > 0 {
-				enums := make([]any, len(values))
-				for i, v := range values {
-					enums[i] = v
-				}
-				schema.Enum = enums
-			}
-
-		case "alpha":
-			schema.Pattern = "^[a-zA-Z]+$"
-
-		case "alphanum":
-			schema.Pattern = "^[a-zA-Z0-9]+$"
-
-		case "alphanumunicode":
-			schema.Pattern = "^[\\p{L}\\p{N}]+$"
-
-		case "alphaunicode":
-			schema.Pattern = "^\\p{L}+$"
-
-		case "numeric":
-			schema.Pattern = "^[0-9]+$"
+		for _, rule := range tagField {
+			definedField[rule.Name] = true
+		}
+		for _, rule := range tagItem {
+			definedItem[rule.Name] = true
+		}
+		fieldRules = append(fieldRules, tagField...)
+		itemRules = append(itemRules, tagItem...)
+	}
 
-		case "hexadecimal":
-			schema.Pattern = "^[0-9a-fA-F]+$"
+	if hasDive {
+		fieldRules = append(fieldRules, ValidationRule{Name: "dive"})
+		fieldRules = append(fieldRules, itemRules...)
+	}
+	return fieldRules
+}
 
-		case "lowercase":
-			schema.Pattern = "^[a-z]+$"
+// applyRulesToSchema applies validation rules to a schema.
+// A validate omitempty takes precedence over required, since the validator
+// skips all rules for zero values in that case. Rules with invalid parameters
+// are reported and skipped.
+func (m *ValidatorMapper) applyRulesToSchema(schema *jsonschema.Schema, rules []ValidationRule, fieldName string) (isRequired bool) {
+	omitEmpty := false
 
-		case "uppercase":
-			schema.Pattern = "^[A-Z]+$"
+	for _, rule := range rules {
+		switch rule.Name {
+		case "required":
+			isRequired = true
 
-		case "contains":
-			if rule.Param != "" {
-				schema.Pattern = regexp.QuoteMeta(rule.Param)
-			}
+		case "omitempty":
+			// Not required, even if combined with required
+			omitEmpty = true
 
-		case "startswith":
-			if rule.Param != "" {
-				schema.Pattern = "^" + regexp.QuoteMeta(rule.Param)
+		default:
+			// Unknown validators are skipped
+			apply := validatorRegistry[rule.Name]
+			if apply == nil {
+				continue
 			}
-
-		case "endswith":
-			if rule.Param != "" {
-				schema.Pattern = regexp.QuoteMeta(rule.Param) + "$"
+			if err := apply(schema, rule); err != nil && m.warnf != nil {
+				m.warnf("field %s: invalid validator %s=%s: %v", fieldName, rule.Name, rule.Param, err)
 			}
+		}
+	}
 
-		// Array validators
-		case "dive":
-			// This is handled in schema building for arrays
-			// The validators after dive apply to array elements
-
-		// Additional common validators
-		case "hostname":
-			schema.Format = "hostname"
-
-		case "fqdn":
-			schema.Format = "hostname"
+	return isRequired && !omitEmpty
+}
 
-		case "json":
-			// JSON string
+// addPattern requires strings to match pattern. A schema holds a single
+// pattern, so further patterns are added as allOf members instead of
+// replacing it.
+func addPattern(schema *jsonschema.Schema, pattern string) {
+	switch schema.Pattern {
+	case "":
+		schema.Pattern = pattern
+	case pattern:
+		// Already required
+	default:
+		schema.AllOf = append(schema.AllOf, &jsonschema.Schema{Pattern: pattern})
+	}
+}
 
-		case "base64":
-			// Base64 encoded string
-			schema.ContentEncoding = "base64"
+// addNotPattern forbids strings matching pattern via a `not` subschema.
+func addNotPattern(schema *jsonschema.Schema, pattern string) {
+	addNot(schema, &jsonschema.Schema{Pattern: pattern})
+}
 
-		case "ascii":
-			schema.Pattern = "^[\\x00-\\x7F]*$"
+// addNot forbids values matching sub via a `not` subschema.
+// Multiple negations are combined with anyOf.
+func addNot(schema *jsonschema.Schema, sub *jsonschema.Schema) {
+	switch {
+	case schema.Not == nil:
+		schema.Not = sub
+	case schema.Not.Pattern != "" || schema.Not.Format != "" || schema.Not.Enum != nil || schema.Not.Const != nil:
+		schema.Not = &jsonschema.Schema{
+			AnyOf: []*jsonschema.Schema{schema.Not, sub},
 		}
+	default:
+		schema.Not.AnyOf = append(schema.Not.AnyOf, sub)
 	}
+}
 
-	return isRequired
+// charClass builds a regex character class matching any of the given characters.
+func charClass(chars string) string {
+	var b strings.Builder
+	b.WriteString("[")
+	for _, r := range chars {
+		switch r {
+		case '\\', ']', '[', '^', '-':
+			b.WriteRune('\\')
+		}
+		b.WriteRune(r)
+	}
+	b.WriteString("]")
+	return b.String()
 }
 
 // ValidationRule represents a parsed validation rule.
@@ -0,0 +1,313 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/invopop/jsonschema"
+)
+
+// ValidatorInfo describes a supported validator and the JSON Schema keyword it maps to.
+type ValidatorInfo struct {
+	Name    string // Validator name as written in the tag (e.g., email)
+	Keyword string // Resulting JSON Schema keyword(s)
+}
+
+// validatorFunc applies a single validation rule to a schema.
+// An error means the rule's parameter was invalid and the rule was skipped.
+type validatorFunc func(schema *jsonschema.Schema, rule ValidationRule) error
+
+// Color patterns of go-playground's hexcolor, rgb, rgba, hsl and hsla validators.
+const (
+	byteValue       = `(?:0|[1-9]\d?|1\d\d?|2[0-4]\d|25[0-5])`
+	percentValue    = `(?:0|[1-9]\d?|1\d\d?|2[0-4]\d|100)%`
+	alphaValue      = `(?:(?:0\.[1-9]*)|[01])`
+	hueValue        = `(?:0|[1-9]\d?|[12]\d\d|3[0-5]\d|360)`
+	rgbChannels     = `(?:` + byteValue + `\s*,\s*` + byteValue + `\s*,\s*` + byteValue + `|` + percentValue + `\s*,\s*` + percentValue + `\s*,\s*` + percentValue + `)`
+	hslChannels     = hueValue + `\s*,\s*(?:(?:0|[1-9]\d?|100)%)\s*,\s*(?:(?:0|[1-9]\d?|100)%)`
+	hexColorPattern = `^#(?:[0-9a-fA-F]{3}|[0-9a-fA-F]{4}|[0-9a-fA-F]{6}|[0-9a-fA-F]{8})$`
+	rgbPattern      = `^rgb\(\s*` + rgbChannels + `\s*\)$`
+	rgbaPattern     = `^rgba\(\s*` + rgbChannels + `\s*,\s*` + alphaValue + `\s*\)$`
+	hslPattern      = `^hsl\(\s*` + hslChannels + `\s*\)$`
+	hslaPattern     = `^hsla\(\s*` + hslChannels + `\s*,\s*` + alphaValue + `\s*\)$`
+)
+
+var (
+	validatorRegistry = make(map[string]validatorFunc)
+	validators        []ValidatorInfo // In registration order, for documentation
+)
+
+// registerValidator registers a handler for one or more validator names.
+// A nil handler marks a validator that is recognized but handled elsewhere (or a no-op).
+func registerValidator(keyword string, fn validatorFunc, names ...string) {
+	for _, name := range names {
+		validatorRegistry[name] = fn
+		validators = append(validators, ValidatorInfo{Name: name, Keyword: keyword})
+	}
+}
+
+// SupportedValidators returns all recognized validators in documentation order.
+func SupportedValidators() []ValidatorInfo {
+	return append([]ValidatorInfo(nil), validators...)
+}
+
+func init() {
+	// Handled by applyRulesToSchema, as they affect required-ness rather than the schema
+	registerValidator("required", nil, "required")
+	registerValidator("removes required", nil, "omitempty")
+
+	// Length and range
+	registerValidator("minLength (string) / minimum (number)", applyMin, "min")
+	registerValidator("maxLength (string) / maximum (number)", applyMax, "max")
+	registerValidator("minLength + maxLength (string) / minItems + maxItems (array) / minProperties + maxProperties (object)", applyLen, "len")
+	registerValidator("minimum", numericBound(func(s *jsonschema.Schema, n json.Number) { s.Minimum = n }), "gte")
+	registerValidator("maximum", numericBound(func(s *jsonschema.Schema, n json.Number) { s.Maximum = n }), "lte")
+	registerValidator("exclusiveMinimum", numericBound(func(s *jsonschema.Schema, n json.Number) { s.ExclusiveMinimum = n }), "gt")
+	registerValidator("exclusiveMaximum", numericBound(func(s *jsonschema.Schema, n json.Number) { s.ExclusiveMaximum = n }), "lt")
+
+	// Formats
+	registerValidator("format: email", setFormat("email"), "email", "email_rfc5322")
+	registerValidator("format: uri", setFormat("uri"), "url", "uri", "http_url")
+	registerValidator("format: uuid", setFormat("uuid"), "uuid", "uuid3", "uuid4", "uuid5")
+	registerValidator("format: ipv4", setFormat("ipv4"), "ipv4")
+	registerValidator("format: ipv6", setFormat("ipv6"), "ipv6")
+	registerValidator("format: ip", setFormat("ip"), "ip") // Could be either, use generic format
+	registerValidator("format: date-time", setFormat("date-time"), "datetime")
+	registerValidator("format: date", setFormat("date"), "date")
+	registerValidator("format: hostname", setFormat("hostname"), "hostname", "fqdn")
+
+	// Enums
+	registerValidator("enum", applyOneOf, "oneof")
+
+	// Patterns
+	registerValidator("pattern", setPattern("^[a-zA-Z]+$"), "alpha")
+	registerValidator("pattern", setPattern("^[a-zA-Z0-9]+$"), "alphanum")
+	registerValidator("pattern", setPattern("^[\\p{L}\\p{N}]+$"), "alphanumunicode")
+	registerValidator("pattern", setPattern("^\\p{L}+$"), "alphaunicode")
+	registerValidator("pattern", setPattern("^[0-9]+$"), "numeric")
+	registerValidator("pattern", setPattern("^[0-9a-fA-F]+$"), "hexadecimal")
+	registerValidator("pattern", setPattern("^[a-z]+$"), "lowercase")
+	registerValidator("pattern", setPattern("^[A-Z]+$"), "uppercase")
+	registerValidator("pattern", setPattern("^[\\x00-\\x7F]*$"), "ascii")
+	registerValidator("pattern", setPattern("^\\+[1-9][0-9]{1,14}$"), "e164")
+	registerValidator("pattern", paramPattern("", "", false), "contains")
+	registerValidator("pattern", paramPattern("^", "", false), "startswith")
+	registerValidator("pattern", paramPattern("", "$", false), "endswith")
+	registerValidator("not.pattern", paramPattern("^", "", true), "startsnotwith")
+	registerValidator("not.pattern", paramPattern("", "$", true), "endsnotwith")
+	registerValidator("not.pattern", paramPattern("", "", true), "excludes", "excludesrune")
+	registerValidator("not.pattern", applyExcludesAll, "excludesall")
+
+	// Colors; iscolor accepts any of the individual color notations
+	registerValidator("pattern", setPattern(hexColorPattern), "hexcolor")
+	registerValidator("pattern", setPattern(rgbPattern), "rgb")
+	registerValidator("pattern", setPattern(rgbaPattern), "rgba")
+	registerValidator("pattern", setPattern(hslPattern), "hsl")
+	registerValidator("pattern", setPattern(hslaPattern), "hsla")
+	registerValidator("anyOf: [{pattern}, ...]", anyPattern(hexColorPattern, rgbPattern, rgbaPattern, hslPattern, hslaPattern), "iscolor")
+
+	// Encodings
+	registerValidator("contentEncoding: base64", applyBase64, "base64")
+	registerValidator("(accepted, no keyword)", nil, "json") // JSON string
+
+	// Handled in ApplyValidation: the validators after dive apply to array elements or map values,
+	// and those between keys and endkeys to map keys
+	registerValidator("items / additionalProperties (rules after dive apply to elements)", nil, "dive")
+	registerValidator("propertyNames (rules until endkeys apply to map keys)", nil, "keys")
+	registerValidator("(ends key rules)", nil, "endkeys")
+}
+
+// isNumericSchema reports whether the schema is an integer or number.
+func isNumericSchema(schema *jsonschema.Schema) bool {
+	return schema.Type == "integer" || schema.Type == "number"
+}
+
+// applyMin maps min to minLength for strings and minimum for numbers.
+func applyMin(schema *jsonschema.Schema, rule ValidationRule) error {
+	val, err := strconv.ParseFloat(rule.Param, 64)
+	if err != nil {
+		return err
+	}
+	if schema.Type == "string" {
+		minLen := uint64(val)
+		schema.MinLength = &minLen
+	} else if isNumericSchema(schema) {
+		schema.Minimum = json.Number(rule.Param)
+	}
+	return nil
+}
+
+// applyMax maps max to maxLength for strings and maximum for numbers.
+func applyMax(schema *jsonschema.Schema, rule ValidationRule) error {
+	val, err := strconv.ParseFloat(rule.Param, 64)
+	if err != nil {
+		return err
+	}
+	if schema.Type == "string" {
+		maxLen := uint64(val)
+		schema.MaxLength = &maxLen
+	} else if isNumericSchema(schema) {
+		schema.Maximum = json.Number(rule.Param)
+	}
+	return nil
+}
+
+// applyLen maps len to an exact string length, number of items or number of properties.
+func applyLen(schema *jsonschema.Schema, rule ValidationRule) error {
+	val, err := strconv.ParseUint(rule.Param, 10, 64)
+	if err != nil {
+		return err
+	}
+	switch schema.Type {
+	case "string":
+		schema.MinLength = &val
+		schema.MaxLength = &val
+	case "array":
+		schema.MinItems = &val
+		schema.MaxItems = &val
+	case "object":
+		schema.MinProperties = &val
+		schema.MaxProperties = &val
+	}
+	return nil
+}
+
+// numericBound returns a handler setting a numeric bound via set.
+func numericBound(set func(*jsonschema.Schema, json.Number)) validatorFunc {
+	return func(schema *jsonschema.Schema, rule ValidationRule) error {
+		if _, err := strconv.ParseFloat(rule.Param, 64); err != nil {
+			return err
+		}
+		if isNumericSchema(schema) {
+			set(schema, json.Number(rule.Param))
+		}
+		return nil
+	}
+}
+
+// setFormat returns a handler setting the given format.
+func setFormat(format string) validatorFunc {
+	return func(schema *jsonschema.Schema, _ ValidationRule) error {
+		schema.Format = format
+		return nil
+	}
+}
+
+// setPattern returns a handler setting a fixed pattern.
+func setPattern(pattern string) validatorFunc {
+	return func(schema *jsonschema.Schema, _ ValidationRule) error {
+		addPattern(schema, pattern)
+		return nil
+	}
+}
+
+// anyPattern returns a handler for composite validators accepting strings
+// that match any of the patterns, emitted as an anyOf of pattern subschemas.
+// An existing anyOf is kept, with the alternatives required via allOf.
+func anyPattern(patterns ...string) validatorFunc {
+	return func(schema *jsonschema.Schema, _ ValidationRule) error {
+		alternatives := make([]*jsonschema.Schema, 0, len(patterns))
+		for _, pattern := range patterns {
+			alternatives = append(alternatives, &jsonschema.Schema{Pattern: pattern})
+		}
+		if schema.AnyOf == nil {
+			schema.AnyOf = alternatives
+		} else {
+			schema.AllOf = append(schema.AllOf, &jsonschema.Schema{AnyOf: alternatives})
+		}
+		return nil
+	}
+}
+
+// paramPattern returns a handler building a pattern from the quoted rule parameter.
+// Substring rules only apply to strings, so on arrays and maps they are
+// ignored; after a dive they reach the string items via the item schema.
+// Negated patterns are combined via addNotPattern.
+func paramPattern(prefix, suffix string, negate bool) validatorFunc {
+	return func(schema *jsonschema.Schema, rule ValidationRule) error {
+		if rule.Param == "" || schema.Type != "string" {
+			return nil
+		}
+		pattern := prefix + regexp.QuoteMeta(rule.Param) + suffix
+		if negate {
+			addNotPattern(schema, pattern)
+		} else {
+			addPattern(schema, pattern)
+		}
+		return nil
+	}
+}
+
+// applyExcludesAll forbids any of the given characters.
+func applyExcludesAll(schema *jsonschema.Schema, rule ValidationRule) error {
+	if rule.Param != "" && schema.Type == "string" {
+		addNotPattern(schema, charClass(rule.Param))
+	}
+	return nil
+}
+
+// applyOneOf maps space-separated oneof values to an enum.
+// An existing enum (e.g., from typed constants) is narrowed to the oneof values.
+func applyOneOf(schema *jsonschema.Schema, rule ValidationRule) error {
+	values := strings.Fields(rule.Param)
+	if len(values) > 0 {
+		enums := make([]any, len(values))
+		for i, v := range values {
+			enums[i] = v
+			// Numeric fields, including integer aliases, get numeric enum values
+			if isNumericSchema(schema) {
+				if _, err := strconv.ParseFloat(v, 64); err != nil {
+					return err
+				}
+				enums[i] = json.Number(v)
+			}
+		}
+		if len(schema.Enum) > 0 {
+			intersectEnum(schema, enums)
+		} else {
+			schema.Enum = enums
+		}
+	}
+	return nil
+}
+
+// intersectEnum keeps the enum values also listed in allowed, along with
+// their entries in the parallel x-enum-varnames and x-enumNames extensions.
+func intersectEnum(schema *jsonschema.Schema, allowed []any) {
+	keep := make(map[string]bool, len(allowed))
+	for _, v := range allowed {
+		keep[fmt.Sprint(v)] = true
+	}
+
+	var indices []int
+	for i, v := range schema.Enum {
+		if keep[fmt.Sprint(v)] {
+			indices = append(indices, i)
+		}
+	}
+
+	schema.Enum = pick(schema.Enum, indices)
+	for _, key := range []string{"x-enum-varnames", "x-enumNames"} {
+		if names, ok := schema.Extras[key].([]string); ok {
+			schema.Extras[key] = pick(names, indices)
+		}
+	}
+}
+
+// pick returns the elements of s at the given indices.
+func pick[T any](s []T, indices []int) []T {
+	picked := make([]T, 0, len(indices))
+	for _, i := range indices {
+		picked = append(picked, s[i])
+	}
+	return picked
+}
+
+// applyBase64 marks a string as base64 encoded.
+func applyBase64(schema *jsonschema.Schema, _ ValidationRule) error {
+	schema.ContentEncoding = "base64"
+	return nil
+}
@@ -0,0 +1,190 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/invopop/jsonschema"
+	"github.com/ron96g/json-schema-gen/internal/parser"
+)
+
+// validatorCase applies a validator to a schema and compares the result.
+type validatorCase struct {
+	name    string
+	fn      validatorFunc
+	schema  *jsonschema.Schema
+	param   string
+	want    *jsonschema.Schema
+	wantErr bool
+}
+
+func runValidatorCases(t *testing.T, cases []validatorCase) {
+	t.Helper()
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.fn(tc.schema, ValidationRule{Param: tc.param})
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("error = %v, want error %v", err, tc.wantErr)
+			}
+			if got, want := marshalSchema(t, tc.schema), marshalSchema(t, tc.want); got != want {
+				t.Errorf("schema = %s, want %s", got, want)
+			}
+		})
+	}
+}
+
+func marshalSchema(t *testing.T, schema *jsonschema.Schema) string {
+	t.Helper()
+	data, err := json.Marshal(schema)
+	if err != nil {
+		t.Fatalf("marshal schema: %v", err)
+	}
+	return string(data)
+}
+
+func uintPtr(n uint64) *uint64 {
+	return &n
+}
+
+func TestApplyMinMax(t *testing.T) {
+	runValidatorCases(t, []validatorCase{
+		{name: "min string", fn: applyMin, schema: &jsonschema.Schema{Type: "string"}, param: "3",
+			want: &jsonschema.Schema{Type: "string", MinLength: uintPtr(3)}},
+		{name: "min integer", fn: applyMin, schema: &jsonschema.Schema{Type: "integer"}, param: "1",
+			want: &jsonschema.Schema{Type: "integer", Minimum: "1"}},
+		{name: "min number keeps param", fn: applyMin, schema: &jsonschema.Schema{Type: "number"}, param: "0.5",
+			want: &jsonschema.Schema{Type: "number", Minimum: "0.5"}},
+		{name: "min array ignored", fn: applyMin, schema: &jsonschema.Schema{Type: "array"}, param: "1",
+			want: &jsonschema.Schema{Type: "array"}},
+		{name: "min invalid", fn: applyMin, schema: &jsonschema.Schema{Type: "string"}, param: "abc",
+			want: &jsonschema.Schema{Type: "string"}, wantErr: true},
+		{name: "max string", fn: applyMax, schema: &jsonschema.Schema{Type: "string"}, param: "10",
+			want: &jsonschema.Schema{Type: "string", MaxLength: uintPtr(10)}},
+		{name: "max integer", fn: applyMax, schema: &jsonschema.Schema{Type: "integer"}, param: "100",
+			want: &jsonschema.Schema{Type: "integer", Maximum: "100"}},
+		{name: "max invalid", fn: applyMax, schema: &jsonschema.Schema{Type: "integer"}, param: "",
+			want: &jsonschema.Schema{Type: "integer"}, wantErr: true},
+	})
+}
+
+func TestApplyLen(t *testing.T) {
+	runValidatorCases(t, []validatorCase{
+		{name: "string", fn: applyLen, schema: &jsonschema.Schema{Type: "string"}, param: "2",
+			want: &jsonschema.Schema{Type: "string", MinLength: uintPtr(2), MaxLength: uintPtr(2)}},
+		{name: "array", fn: applyLen, schema: &jsonschema.Schema{Type: "array"}, param: "3",
+			want: &jsonschema.Schema{Type: "array", MinItems: uintPtr(3), MaxItems: uintPtr(3)}},
+		{name: "object", fn: applyLen, schema: &jsonschema.Schema{Type: "object"}, param: "1",
+			want: &jsonschema.Schema{Type: "object", MinProperties: uintPtr(1), MaxProperties: uintPtr(1)}},
+		{name: "integer ignored", fn: applyLen, schema: &jsonschema.Schema{Type: "integer"}, param: "4",
+			want: &jsonschema.Schema{Type: "integer"}},
+		{name: "negative", fn: applyLen, schema: &jsonschema.Schema{Type: "string"}, param: "-1",
+			want: &jsonschema.Schema{Type: "string"}, wantErr: true},
+	})
+}
+
+func TestNumericBound(t *testing.T) {
+	gte := validatorRegistry["gte"]
+	gt := validatorRegistry["gt"]
+	runValidatorCases(t, []validatorCase{
+		{name: "gte integer", fn: gte, schema: &jsonschema.Schema{Type: "integer"}, param: "0",
+			want: &jsonschema.Schema{Type: "integer", Minimum: "0"}},
+		{name: "gt number", fn: gt, schema: &jsonschema.Schema{Type: "number"}, param: "1.5",
+			want: &jsonschema.Schema{Type: "number", ExclusiveMinimum: "1.5"}},
+		{name: "gte string ignored", fn: gte, schema: &jsonschema.Schema{Type: "string"}, param: "1",
+			want: &jsonschema.Schema{Type: "string"}},
+		{name: "gte invalid", fn: gte, schema: &jsonschema.Schema{Type: "integer"}, param: "now",
+			want: &jsonschema.Schema{Type: "integer"}, wantErr: true},
+	})
+}
+
+func TestApplyOneOf(t *testing.T) {
+	runValidatorCases(t, []validatorCase{
+		{name: "strings", fn: applyOneOf, schema: &jsonschema.Schema{Type: "string"}, param: "a b",
+			want: &jsonschema.Schema{Type: "string", Enum: []any{"a", "b"}}},
+		{name: "integers", fn: applyOneOf, schema: &jsonschema.Schema{Type: "integer"}, param: "1 2",
+			want: &jsonschema.Schema{Type: "integer", Enum: []any{json.Number("1"), json.Number("2")}}},
+		{name: "invalid number", fn: applyOneOf, schema: &jsonschema.Schema{Type: "integer"}, param: "1 x",
+			want: &jsonschema.Schema{Type: "integer"}, wantErr: true},
+		{name: "empty", fn: applyOneOf, schema: &jsonschema.Schema{Type: "string"}, param: "",
+			want: &jsonschema.Schema{Type: "string"}},
+		{name: "narrows existing enum", fn: applyOneOf,
+			schema: &jsonschema.Schema{Type: "string", Enum: []any{"a", "b", "c"},
+				Extras: map[string]any{"x-enum-varnames": []string{"A", "B", "C"}}},
+			param: "c a",
+			want: &jsonschema.Schema{Type: "string", Enum: []any{"a", "c"},
+				Extras: map[string]any{"x-enum-varnames": []string{"A", "C"}}}},
+	})
+}
+
+func TestIntersectEnum(t *testing.T) {
+	schema := &jsonschema.Schema{
+		Type:   "integer",
+		Enum:   []any{1, 2, 3},
+		Extras: map[string]any{"x-enumNames": []string{"One", "Two", "Three"}},
+	}
+	intersectEnum(schema, []any{json.Number("3"), json.Number("2"), json.Number("4")})
+
+	want := &jsonschema.Schema{
+		Type:   "integer",
+		Enum:   []any{2, 3},
+		Extras: map[string]any{"x-enumNames": []string{"Two", "Three"}},
+	}
+	if got, want := marshalSchema(t, schema), marshalSchema(t, want); got != want {
+		t.Errorf("schema = %s, want %s", got, want)
+	}
+}
+
+func TestParamPattern(t *testing.T) {
+	runValidatorCases(t, []validatorCase{
+		{name: "contains quotes meta", fn: paramPattern("", "", false), schema: &jsonschema.Schema{Type: "string"}, param: "a.b",
+			want: &jsonschema.Schema{Type: "string", Pattern: `a\.b`}},
+		{name: "startswith", fn: paramPattern("^", "", false), schema: &jsonschema.Schema{Type: "string"}, param: "id-",
+			want: &jsonschema.Schema{Type: "string", Pattern: "^id-"}},
+		{name: "second pattern goes to allOf", fn: paramPattern("", "$", false),
+			schema: &jsonschema.Schema{Type: "string", Pattern: "^id-"}, param: ".go",
+			want: &jsonschema.Schema{Type: "string", Pattern: "^id-", AllOf: []*jsonschema.Schema{{Pattern: `\.go$`}}}},
+		{name: "negated", fn: paramPattern("^", "", true), schema: &jsonschema.Schema{Type: "string"}, param: "tmp",
+			want: &jsonschema.Schema{Type: "string", Not: &jsonschema.Schema{Pattern: "^tmp"}}},
+		{name: "array ignored", fn: paramPattern("", "", false), schema: &jsonschema.Schema{Type: "array"}, param: "x",
+			want: &jsonschema.Schema{Type: "array"}},
+		{name: "empty param", fn: paramPattern("", "", false), schema: &jsonschema.Schema{Type: "string"}, param: "",
+			want: &jsonschema.Schema{Type: "string"}},
+	})
+}
+
+func TestApplyExcludesAll(t *testing.T) {
+	runValidatorCases(t, []validatorCase{
+		{name: "escapes class characters", fn: applyExcludesAll, schema: &jsonschema.Schema{Type: "string"}, param: "a-]",
+			want: &jsonschema.Schema{Type: "string", Not: &jsonschema.Schema{Pattern: `[a\-\]]`}}},
+		{name: "combines negations", fn: applyExcludesAll,
+			schema: &jsonschema.Schema{Type: "string", Not: &jsonschema.Schema{Pattern: "^tmp"}}, param: "!",
+			want: &jsonschema.Schema{Type: "string", Not: &jsonschema.Schema{AnyOf: []*jsonschema.Schema{{Pattern: "^tmp"}, {Pattern: "[!]"}}}}},
+		{name: "integer ignored", fn: applyExcludesAll, schema: &jsonschema.Schema{Type: "integer"}, param: "!",
+			want: &jsonschema.Schema{Type: "integer"}},
+	})
+}
+
+func TestInvalidRuleWarning(t *testing.T) {
+	var warnings []string
+	m := NewValidatorMapper()
+	m.warnf = func(format string, args ...any) {
+		warnings = append(warnings, fmt.Sprintf(format, args...))
+	}
+	field := parser.FieldInfo{
+		Name: "Count",
+		Type: parser.TypeInfo{Kind: parser.TypeKindSlice, Name: "[]int"},
+		Tags: map[string]string{"validate": "min=abc,dive,oneof=1 x"},
+	}
+	schema := &jsonschema.Schema{Type: "array", Items: &jsonschema.Schema{Type: "integer"}}
+	m.ApplyValidation(schema, field)
+
+	want := []string{
+		`field Count: invalid validator oneof=1 x: strconv.ParseFloat: parsing "x": invalid syntax`,
+		`field Count: invalid validator min=abc: strconv.ParseFloat: parsing "abc": invalid syntax`,
+	}
+	if !slices.Equal(warnings, want) {
+		t.Errorf("warnings = %q, want %q", warnings, want)
+	}
+}
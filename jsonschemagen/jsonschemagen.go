@@ -0,0 +1,61 @@
+// Package jsonschemagen generates JSON Schema files from annotated Go structs
+// with the same options as the json-schema-gen command, for programs that
+// need to customize generation, e.g. with schemas for third-party types.
+//
+//	g := jsonschemagen.NewGenerator(jsonschemagen.Config{OutputDir: "schemas"})
+//	g.RegisterTypeHandler(func(typeInfo jsonschemagen.TypeInfo) *jsonschema.Schema {
+//		if typeInfo.Name != "decimal.Decimal" {
+//			return nil
+//		}
+//		return &jsonschema.Schema{Type: "string", Pattern: `^-?[0-9]+(\.[0-9]+)?$`}
+//	})
+//	err := g.GenerateFromPaths([]string{"./models"})
+package jsonschemagen
+
+import (
+	"github.com/ron96g/json-schema-gen/internal/generator"
+	"github.com/ron96g/json-schema-gen/internal/parser"
+	"github.com/ron96g/json-schema-gen/internal/schema"
+)
+
+type (
+	// Config holds the generator options; see the command's flags for details.
+	Config = generator.Config
+
+	// Generator parses Go sources and writes their schemas.
+	Generator = generator.Generator
+
+	// TypeHandler returns a custom schema for a type, or nil to fall through
+	// to the built-in mapping. It must return a new schema on every call,
+	// since validators and descriptions are applied to the returned schema.
+	TypeHandler = schema.TypeHandler
+
+	// TypeInfo describes the Go type of a field or collection element, with
+	// its name as written in the source (e.g. "decimal.Decimal").
+	TypeInfo = parser.TypeInfo
+
+	// TypeKind is the kind of a Go type.
+	TypeKind = parser.TypeKind
+)
+
+// Kinds of TypeInfo.
+const (
+	TypeKindPrimitive = parser.TypeKindPrimitive
+	TypeKindStruct    = parser.TypeKindStruct
+	TypeKindSlice     = parser.TypeKindSlice
+	TypeKindArray     = parser.TypeKindArray
+	TypeKindMap       = parser.TypeKindMap
+	TypeKindPointer   = parser.TypeKindPointer
+	TypeKindInterface = parser.TypeKindInterface
+	TypeKindTime      = parser.TypeKindTime
+	TypeKindDuration  = parser.TypeKindDuration
+	TypeKindAlias     = parser.TypeKindAlias
+	TypeKindUnknown   = parser.TypeKindUnknown
+)
+
+// NewGenerator creates a Generator. Type handlers registered with its
+// RegisterTypeHandler method are consulted for field and collection element
+// types before the built-in type mapping.
+func NewGenerator(cfg Config) *Generator {
+	return generator.NewGenerator(cfg)
+}
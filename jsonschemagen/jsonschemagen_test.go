@@ -0,0 +1,48 @@
+package jsonschemagen_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/invopop/jsonschema"
+	"github.com/ron96g/json-schema-gen/jsonschemagen"
+)
+
+// TestRegisterTypeHandler checks that a handler registered through the
+// public API replaces the schema of the types it accepts.
+func TestRegisterTypeHandler(t *testing.T) {
+	out := t.TempDir()
+	g := jsonschemagen.NewGenerator(jsonschemagen.Config{OutputDir: out})
+	g.RegisterTypeHandler(func(typeInfo jsonschemagen.TypeInfo) *jsonschema.Schema {
+		if typeInfo.Kind != jsonschemagen.TypeKindStruct || typeInfo.Name != "decimal.Decimal" {
+			return nil
+		}
+		return &jsonschema.Schema{Type: "string", Pattern: `^-?[0-9]+(\.[0-9]+)?$`}
+	})
+	if err := g.GenerateFromPaths([]string{filepath.Join("testdata", "invoice")}); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(out, "invoice.schema.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got struct {
+		Properties map[string]any `json:"properties"`
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	decimal := map[string]any{"type": "string", "pattern": `^-?[0-9]+(\.[0-9]+)?$`}
+	want := map[string]any{
+		"total": decimal,
+		"lines": map[string]any{"type": "array", "items": decimal},
+	}
+	if !reflect.DeepEqual(got.Properties, want) {
+		t.Errorf("properties = %v, want %v", got.Properties, want)
+	}
+}
@@ -0,0 +1,9 @@
+package invoice
+
+import "github.com/shopspring/decimal"
+
+// +schema
+type Invoice struct {
+	Total decimal.Decimal   `json:"total" validate:"required"`
+	Lines []decimal.Decimal `json:"lines"`
+}
@@ -12,12 +12,19 @@ package main
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"text/tabwriter"
 
 	"github.com/ron96g/json-schema-gen/internal/cli"
 	"github.com/ron96g/json-schema-gen/internal/generator"
+	"github.com/ron96g/json-schema-gen/internal/schema"
 )
 
+// version is the tool version, injected at build time via
+// -ldflags "-X main.version=v1.2.3".
+var version = "dev"
+
 func main() {
 	if err := run(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -31,13 +38,85 @@ func run() error {
 		return err
 	}
 
+	if cfg.HelpValidators {
+		return printValidators(os.Stdout)
+	}
+
 	genCfg := generator.Config{
-		OutputDir: cfg.OutputDir,
-		NameTag:   cfg.NameTag,
-		SchemaID:  cfg.SchemaID,
-		Recursive: cfg.Recursive,
+		OutputDir:          cfg.OutputDir,
+		NameTag:            cfg.NameTag,
+		SchemaID:           cfg.SchemaID,
+		SchemaURI:          cfg.SchemaURI,
+		Recursive:          cfg.Recursive,
+		CrossModule:        cfg.CrossModule,
+		PropertyCase:       cfg.PropertyCase,
+		StripPrefix:        cfg.StripPrefix,
+		QualifyRefs:        cfg.QualifyRefs,
+		CommentDirectives:  cfg.CommentDirectives,
+		PreserveNewlines:   cfg.PreserveNewlines,
+		IncludeTests:       cfg.IncludeTests,
+		IncludeTestdata:    cfg.IncludeTestdata,
+		IncludeVendor:      cfg.IncludeVendor,
+		PackageMode:        cfg.PackageMode,
+		OpenAPIVersion:     cfg.OpenAPIVersion,
+		NullablePointers:   cfg.NullablePointers,
+		FlattenSingleField: cfg.FlattenSingleField,
+		HumanizeTitles:     cfg.HumanizeTitles,
+		OutputRelativeTo:   cfg.OutputRelativeTo,
+		FailOnWarning:      cfg.FailOnWarning,
+		ValidationTags:     cfg.TagPriority,
+		IntrinsicBounds:    cfg.IntrinsicBounds,
+		RuneAsString:       cfg.RuneAsString,
+		HoistThreshold:     cfg.HoistThreshold,
+		BuildTags:          cfg.BuildTags,
+		MarshalerAs:        cfg.MarshalerAs,
+		TypeMappings:       cfg.TypeMappings,
+		Formats:            cfg.Formats,
+		Extension:          cfg.Extension,
+		BaseRef:            cfg.BaseRef,
+		DocumentedEnums:    cfg.DocumentedEnums,
+		EnumVarnames:       cfg.EnumVarnames,
+		EnumNames:          cfg.EnumNames,
+		RefStyle:           cfg.RefStyle,
+		KeepGoing:          cfg.KeepGoing,
+		MaxErrors:          cfg.MaxErrors,
+		Only:               cfg.Only,
+		Skip:               cfg.Skip,
+		NoRequired:         cfg.NoRequired,
+		Incremental:        cfg.Incremental,
+		EmbedMode:          cfg.EmbedMode,
+		Since:              cfg.Since,
+		RequiredNonEmpty:   cfg.RequiredNonEmpty,
+		TitleFromComment:   cfg.TitleFromComment,
+		ExamplesFromEnum:   cfg.ExamplesFromEnum,
+		ReadOnlyFields:     cfg.ReadOnlyFields,
+		WarnUnmappedTypes:  cfg.WarnUnmappedTypes,
+		AutoFieldTitles:    cfg.AutoFieldTitles,
+		InputFormat:        cfg.InputFormat,
+		EmitTSEnums:        cfg.EmitTSEnums,
+		DeterministicMaps:  cfg.DeterministicMaps,
+		ExcludeFields:      cfg.ExcludeFields,
+		RegistryFile:       cfg.RegistryFile,
+		AddSchemaComment:   cfg.AddSchemaComment,
+		GroupOutputBy:      cfg.GroupOutputBy,
+
+		RequiredUnlessOmitEmpty: cfg.RequiredUnlessOmitEmpty,
+		RequiredStringsNonEmpty: cfg.RequiredStringsNonEmpty,
+	}
+	if cfg.Stamp {
+		genCfg.Stamp = "json-schema-gen " + version
 	}
 
 	gen := generator.NewGenerator(genCfg)
 	return gen.GenerateFromPaths(cfg.Paths)
 }
+
+// printValidators writes the supported validator mappings as a table.
+func printValidators(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "VALIDATOR\tJSON SCHEMA")
+	for _, v := range schema.SupportedValidators() {
+		fmt.Fprintf(tw, "%s\t%s\n", v.Name, v.Keyword)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// TestPrintValidators checks that --help-validators lists validators with
+// the keywords they produce.
+func TestPrintValidators(t *testing.T) {
+	var buf bytes.Buffer
+	if err := printValidators(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	keywords := make(map[string]string)
+	for _, line := range strings.Split(buf.String(), "\n")[1:] {
+		name, keyword, ok := strings.Cut(line, "  ")
+		if ok {
+			keywords[name] = strings.TrimSpace(keyword)
+		}
+	}
+
+	for name, want := range map[string]string{
+		"email": "format: email",
+		"oneof": "enum",
+	} {
+		if got := keywords[name]; got != want {
+			t.Errorf("%s keyword = %q, want %q", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package allrequired
+
+// Profile is a user profile where everything is required by default.
+// +schema
+type Profile struct {
+	Name     string   `json:"name"`
+	Age      int      `json:"age"`
+	Verified bool     `json:"verified"`
+	Emails   []string `json:"emails"`
+	Contact  Contact  `json:"contact"`
+	// Optional because of omitempty
+	Bio string `json:"bio,omitempty"`
+	// Optional because it is a pointer
+	Nickname *string `json:"nickname"`
+}
+
+// Contact holds contact details.
+type Contact struct {
+	Phone string `json:"phone"`
+	Fax   string `json:"fax,omitempty"`
+}
@@ -0,0 +1,28 @@
+package anyof
+
+// Notifier delivers notifications.
+type Notifier interface {
+	Notify(message string) error
+}
+
+// EmailTarget sends notifications by email.
+type EmailTarget struct {
+	Address string `json:"address" validate:"required,email"`
+}
+
+// WebhookTarget posts notifications to a URL.
+type WebhookTarget struct {
+	URL    string `json:"url" validate:"required,url"`
+	Secret string `json:"secret,omitempty"`
+}
+
+// Alert is delivered to any kind of notifier.
+// +schema
+type Alert struct {
+	Name string `json:"name" validate:"required"`
+	// Primary receives the alert first
+	// +schema:anyof EmailTarget WebhookTarget
+	Primary Notifier `json:"primary"`
+	// Fallbacks are tried in order
+	Fallbacks []Notifier `json:"fallbacks,omitempty"` // +schema:anyof EmailTarget WebhookTarget
+}
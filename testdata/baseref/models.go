@@ -0,0 +1,9 @@
+// Package baseref contains structs generated with --base-ref.
+package baseref
+
+// +schema
+// Invoice extends the shared base resource schema.
+type Invoice struct {
+	Number string  `json:"number" validate:"required"`
+	Total  float64 `json:"total" validate:"gte=0"`
+}
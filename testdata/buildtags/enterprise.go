@@ -0,0 +1,9 @@
+//go:build enterprise
+
+package buildtags
+
+// +schema
+// License is only generated with the enterprise build tag.
+type License struct {
+	Seats int `json:"seats"`
+}
@@ -0,0 +1,9 @@
+// Package buildtags is generated into default without build tags and into
+// enterprise with --build-tags enterprise.
+package buildtags
+
+// +schema
+// Account is always generated.
+type Account struct {
+	Name string `json:"name"`
+}
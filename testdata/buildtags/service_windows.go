@@ -0,0 +1,7 @@
+package buildtags
+
+// +schema
+// WindowsService is skipped unless GOOS is windows, by its file name suffix.
+type WindowsService struct {
+	Name string `json:"name"`
+}
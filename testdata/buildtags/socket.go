@@ -0,0 +1,9 @@
+//go:build unix
+
+package buildtags
+
+// +schema
+// Socket is generated on unix systems, which satisfy the unix constraint.
+type Socket struct {
+	Path string `json:"path"`
+}
@@ -0,0 +1,32 @@
+// Package catchall contains open structs whose extra properties are collected in a map.
+package catchall
+
+// +schema
+// +schema:additional-properties=Labels
+// Resource accepts any extra string properties, collected into Labels.
+type Resource struct {
+	Name   string            `json:"name" validate:"required"`
+	Labels map[string]string `json:"-"`
+}
+
+// +schema
+// +schema:additional-properties=Extra
+// Event accepts extra properties of any type.
+type Event struct {
+	Type  string         `json:"type"`
+	Extra map[string]any `json:"-"`
+}
+
+// +schema:inline
+// +schema:additional-properties=Metrics
+// Report accepts extra properties that are Metric objects, inlined.
+type Report struct {
+	Title   string            `json:"title"`
+	Metrics map[string]Metric `json:"metrics,omitempty"`
+}
+
+// Metric is a measured value.
+type Metric struct {
+	Value float64 `json:"value"`
+	Unit  string  `json:"unit"`
+}
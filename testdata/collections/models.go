@@ -0,0 +1,27 @@
+package collections
+
+// User is a registered user.
+// +schema
+type User struct {
+	ID   string `json:"id" validate:"required,uuid"`
+	Name string `json:"name" validate:"required"`
+}
+
+// Users is the payload of the list endpoint.
+// +schema
+type Users []User
+
+// UsersByTeam groups users by team name.
+// +schema
+type UsersByTeam map[string][]User
+
+// Tags are free-form labels.
+type Tags []string
+
+// Team is a named group of users.
+// +schema:inline
+type Team struct {
+	Name    string `json:"name" validate:"required"`
+	Members Users  `json:"members"`
+	Tags    Tags   `json:"tags,omitempty"`
+}
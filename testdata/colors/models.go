@@ -0,0 +1,14 @@
+package colors
+
+// Theme holds the colors of a UI theme.
+// +schema
+type Theme struct {
+	// Any CSS color notation
+	Primary string `json:"primary" validate:"required,iscolor"`
+
+	Accent string `json:"accent" validate:"hexcolor"`
+
+	Overlay string `json:"overlay,omitempty" validate:"omitempty,rgba"`
+
+	Palette []string `json:"palette" validate:"dive,iscolor"`
+}
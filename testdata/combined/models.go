@@ -0,0 +1,24 @@
+package combined
+
+// Level is a log level.
+type Level string
+
+const (
+	LevelDebug Level = "debug"
+	LevelInfo  Level = "info"
+	LevelWarn  Level = "warn"
+	LevelError Level = "error"
+)
+
+// Subscription combines enums with other validators.
+// +schema
+type Subscription struct {
+	// Contact is one of the team mailboxes
+	Contact string `json:"contact" validate:"required,oneof=ops@example.com dev@example.com,email"`
+	// Plan is a named plan with a minimum length
+	Plan string `json:"plan" validate:"oneof=basic premium,min=1"`
+	// Prefix must both start and end with a fixed text
+	Prefix string `json:"prefix,omitempty" validate:"startswith=sub-,endswith=-v1"`
+	// Level is restricted to a subset of the typed constants
+	Level Level `json:"level,omitempty" validate:"oneof=warn error"`
+}
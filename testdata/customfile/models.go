@@ -0,0 +1,17 @@
+package customfile
+
+// ShippingAddress is where orders are delivered.
+// +schema
+// +schema:file=address-v2.schema.json
+type ShippingAddress struct {
+	Street string `json:"street"`
+	City   string `json:"city"`
+}
+
+// Order references the address by its custom filename.
+// +schema
+type Order struct {
+	ID       string            `json:"id"`
+	Shipping ShippingAddress   `json:"shipping"`
+	Previous []ShippingAddress `json:"previous"`
+}
@@ -0,0 +1,14 @@
+package dependent
+
+// Payment is paid by card or bank transfer.
+// +schema
+// +schema:dependent=card_number:expiry,cvc
+// +schema:dependent=IBAN:account_holder
+type Payment struct {
+	Amount        int    `json:"amount" validate:"required"`
+	CardNumber    string `json:"card_number,omitempty"`
+	Expiry        string `json:"expiry,omitempty"`
+	CVC           string `json:"cvc,omitempty"`
+	IBAN          string `json:"iban,omitempty"`
+	AccountHolder string `json:"account_holder,omitempty"`
+}
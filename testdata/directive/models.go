@@ -0,0 +1,12 @@
+// Package directive contains structs whose generation options come from the
+// go:generate directive below when run as "json-schema-gen testdata/directive".
+package directive
+
+//go:generate json-schema-gen --output-dir . --humanize-titles --tag yaml
+
+// +schema
+// APIKey is generated with humanized titles and yaml property names.
+type APIKey struct {
+	KeyID     string `yaml:"key_id" validate:"required"`
+	ExpiresIn int    `yaml:"expires_in" validate:"gte=0"`
+}
@@ -0,0 +1,17 @@
+// Package directives contains comments mixing documentation and tool directives.
+package directives
+
+// +schema
+// Credentials holds secrets for an upstream service.
+//
+//nolint:gosec // Field names look like hardcoded credentials
+//custom:audit
+type Credentials struct {
+	// Username for the upstream service
+	//nolint:revive
+	Username string `json:"username" validate:"required"`
+	Password string `json:"password" validate:"required"` //nolint:gosec
+	// Token issued by the upstream service
+	//lint:ignore U1000 kept for compatibility
+	Token string `json:"token"`
+}
@@ -0,0 +1,17 @@
+package divecontains
+
+// Mailing is a mailing list.
+// +schema
+type Mailing struct {
+	// Each recipient must contain an @
+	Recipients []string `json:"recipients" validate:"required,dive,contains=@"`
+
+	// Without dive, contains does not apply to the array itself
+	Aliases []string `json:"aliases" validate:"contains=@"`
+
+	Domains map[string]string `json:"domains" validate:"dive,keys,startswith=mx,endkeys,endswith=.org"`
+
+	Groups [][]string `json:"groups" validate:"dive,dive,contains=@,excludes=+"`
+
+	Sender string `json:"sender" validate:"contains=@"`
+}
@@ -0,0 +1,49 @@
+package embed
+
+import "time"
+
+// Timestamps records when a resource was created and last updated.
+type Timestamps struct {
+	CreatedAt time.Time `json:"createdAt" validate:"required"`
+	UpdatedAt time.Time `json:"updatedAt,omitempty"`
+}
+
+// Resource holds the fields shared by all stored entities.
+type Resource struct {
+	Timestamps
+	ID   string `json:"id" validate:"required,uuid"`
+	Kind string `json:"kind"`
+}
+
+// Owner identifies the user owning a resource.
+type Owner struct {
+	OwnerID string `json:"ownerId" validate:"required"`
+}
+
+// Document is a stored document. Its own Kind shadows the embedded one.
+// +schema
+type Document struct {
+	Resource
+	*Owner
+	Kind  string `json:"kind" validate:"oneof=note report"`
+	Title string `json:"title" validate:"required,max=200"`
+}
+
+// Link is a stored link whose embedded metadata keeps its own property.
+// +schema
+type Link struct {
+	Resource `json:"resource"`
+	URL      string `json:"url" validate:"required,url"`
+}
+
+// Audit records who changed a resource.
+type Audit struct {
+	ChangedBy string `json:"changedBy"`
+}
+
+// Comment is flattened in both embed modes because of the inline option.
+// +schema
+type Comment struct {
+	Audit `json:",inline"`
+	Body  string `json:"body" validate:"required"`
+}
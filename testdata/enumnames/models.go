@@ -0,0 +1,27 @@
+package enumnames
+
+// TaskStatus is the state of a task.
+type TaskStatus string
+
+const (
+	TaskStatusTodo       TaskStatus = "todo"
+	TaskStatusInProgress TaskStatus = "in_progress"
+	TaskStatusDone       TaskStatus = "done"
+)
+
+// Priority keeps constant names without a type prefix.
+type Priority int
+
+const (
+	Low Priority = iota
+	High
+)
+
+// Task is a unit of work.
+// +schema
+type Task struct {
+	Status   TaskStatus `json:"status"`
+	Priority Priority   `json:"priority"`
+	// oneof values have no constant names
+	Size string `json:"size" validate:"oneof=S M L"`
+}
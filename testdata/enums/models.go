@@ -0,0 +1,27 @@
+// Package enums contains commented enum constants generated with --documented-enums.
+package enums
+
+// Priority of a ticket
+type Priority int
+
+const (
+	PriorityLow    Priority = 1 // Handled when time permits
+	PriorityMedium Priority = 2 // Handled this sprint
+	PriorityHigh   Priority = 3 // Handled immediately
+)
+
+// Channel a ticket was opened through (undocumented values stay a plain enum)
+type Channel string
+
+const (
+	ChannelEmail Channel = "email"
+	ChannelPhone Channel = "phone"
+)
+
+// +schema
+// Ticket is a support request.
+type Ticket struct {
+	Title    string   `json:"title" validate:"required"`
+	Priority Priority `json:"priority" validate:"required"`
+	Channel  Channel  `json:"channel"`
+}
@@ -0,0 +1,12 @@
+package exclude
+
+// Job is a background job.
+// +schema
+type Job struct {
+	ID            string `json:"id" validate:"required"`
+	Name          string `json:"name"`
+	InternalState string `json:"internal_state"`
+	InternalRetry int    `json:"internal_retry"`
+	Debug         bool   `json:"debug"`
+	Secret        string `json:"-"`
+}
@@ -0,0 +1,15 @@
+// Package extension contains structs generated with --extension .json.
+package extension
+
+// +schema
+// Order references a Customer, which must resolve to customer.json.
+type Order struct {
+	ID       string   `json:"id" validate:"required,uuid"`
+	Customer Customer `json:"customer" validate:"required"`
+}
+
+// +schema
+// Customer is referenced by Order.
+type Customer struct {
+	Name string `json:"name" validate:"required"`
+}
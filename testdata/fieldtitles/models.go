@@ -0,0 +1,12 @@
+package fieldtitles
+
+// ShippingAddress is rendered as a form.
+// +schema
+type ShippingAddress struct {
+	FullName string `json:"full_name" validate:"required"`
+	ZipCode  string `json:"zip_code"`
+	Country  string `json:"countryCode"`
+	HTTPPort int    `json:"HTTPPort,omitempty"`
+	// Notes for the courier
+	Notes string `json:"notes,omitempty"`
+}
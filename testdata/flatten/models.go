@@ -0,0 +1,35 @@
+// Package flatten contains single-field wrappers flattened with --flatten-single-field.
+package flatten
+
+// Email wraps an email address.
+type Email struct {
+	Value string `json:"value" validate:"email"`
+}
+
+// Tags wraps a list of labels.
+type Tags struct {
+	Items []string `json:"items" validate:"dive,min=1"`
+}
+
+// +schema
+// Contact refers to its wrappers, which are replaced by the wrapped field.
+type Contact struct {
+	// Primary email address
+	Email     Email   `json:"email" validate:"required"`
+	Secondary []Email `json:"secondary"`
+	Tags      *Tags   `json:"tags"`
+	Address   Address `json:"address"`
+}
+
+// +schema
+// Address has more than one field and is still referenced.
+type Address struct {
+	Street string `json:"street"`
+	City   string `json:"city"`
+}
+
+// +schema
+// Phone is a generated wrapper; its own schema is not flattened.
+type Phone struct {
+	Number string `json:"number" validate:"e164"`
+}
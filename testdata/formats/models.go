@@ -0,0 +1,17 @@
+// Package formats contains structs generated as both JSON and YAML with --format json,yaml.
+package formats
+
+// +schema
+// Order placed by a customer.
+type Order struct {
+	ID       string   `json:"id" validate:"required"`
+	Customer Customer `json:"customer"`
+	Notes    string   `json:"notes" validate:"max=200"`
+}
+
+// +schema
+// Customer who places orders.
+type Customer struct {
+	Name  string `json:"name" validate:"required"`
+	Email string `json:"email" validate:"omitempty,email"`
+}
@@ -0,0 +1,21 @@
+// Package formtags contains gin binding structs generated with --tag form.
+// Options after the name, such as gin's default=, leave the property name
+// unchanged.
+package formtags
+
+// +schema
+// SearchRequest binds query or form parameters.
+type SearchRequest struct {
+	// Search terms
+	Query string `form:"q" json:"query" validate:"required"`
+
+	// Page number, defaulting to 1
+	Page int `form:"page,default=1" validate:"min=1"`
+
+	Size int `form:"size,omitempty,default=20" validate:"max=100"`
+
+	Debug bool `form:"-"`
+
+	// Untagged fields keep their Go name
+	Verbose bool
+}
@@ -0,0 +1,7 @@
+package glob
+
+// +schema
+// Legacy is not matched by the glob, so no schema is generated for it.
+type Legacy struct {
+	ID string `json:"id"`
+}
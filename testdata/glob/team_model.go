@@ -0,0 +1,8 @@
+package glob
+
+// +schema
+// Team is matched by the glob and references User from another matched file.
+type Team struct {
+	Name    string `json:"name" validate:"required"`
+	Members []User `json:"members"`
+}
@@ -0,0 +1,8 @@
+// Package glob contains models selected with a quoted glob path argument.
+package glob
+
+// +schema
+// User is matched by the glob.
+type User struct {
+	Name string `json:"name" validate:"required"`
+}
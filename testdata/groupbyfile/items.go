@@ -0,0 +1,8 @@
+package groupbyfile
+
+// Item is a line of an order.
+// +schema
+type Item struct {
+	SKU      string `json:"sku"`
+	Quantity int    `json:"quantity" validate:"min=1"`
+}
@@ -0,0 +1,21 @@
+package groupbyfile
+
+// Customer places orders.
+// +schema
+type Customer struct {
+	Name    string  `json:"name" validate:"required"`
+	Address Address `json:"address"`
+}
+
+// Order is placed by a customer.
+// +schema
+type Order struct {
+	ID       string   `json:"id" validate:"required"`
+	Customer Customer `json:"customer"`
+	Items    []Item   `json:"items"`
+}
+
+// Address is bundled because Customer references it.
+type Address struct {
+	City string `json:"city"`
+}
@@ -0,0 +1,50 @@
+// Package hoist contains inline structs generated with --hoist-threshold 2.
+package hoist
+
+// +schema:inline
+// Shipment uses Location twice, so it is hoisted into $defs.
+type Shipment struct {
+	ID     string   `json:"id" validate:"required"`
+	Origin Location `json:"origin" validate:"required"`
+	Target Location `json:"target" validate:"required"`
+	Parcel Parcel   `json:"parcel"`
+}
+
+// Location is a geographic point.
+type Location struct {
+	Lat float64 `json:"lat" validate:"gte=-90,lte=90"`
+	Lng float64 `json:"lng" validate:"gte=-180,lte=180"`
+}
+
+// Parcel is used once and stays inlined.
+type Parcel struct {
+	Weight float64 `json:"weight" validate:"gt=0"`
+}
+
+// +schema:inline
+// Route hoists several types; $defs keys are written in sorted order
+// regardless of declaration or traversal order.
+type Route struct {
+	Zone     Zone     `json:"zone"`
+	Carrier  Carrier  `json:"carrier"`
+	Backup   Carrier  `json:"backup"`
+	Stops    []Stop   `json:"stops"`
+	Fallback Zone     `json:"fallback"`
+	Origin   Location `json:"origin"`
+}
+
+// Zone is a delivery zone.
+type Zone struct {
+	Code string `json:"code" validate:"required"`
+}
+
+// Carrier delivers parcels.
+type Carrier struct {
+	Name string `json:"name" validate:"required"`
+	Zone Zone   `json:"zone"`
+}
+
+// Stop is a waypoint of a route.
+type Stop struct {
+	At Location `json:"at"`
+}
@@ -0,0 +1,22 @@
+// Package humanize contains structs whose titles are generated with --humanize-titles.
+package humanize
+
+// +schema
+type HTTPServer struct {
+	Addr string `json:"addr"`
+}
+
+// +schema
+type ServiceConfig struct {
+	Name string `json:"name"`
+}
+
+// +schema
+type UserIDs struct {
+	IDs []string `json:"ids"`
+}
+
+// +schema
+type APIKeys struct {
+	Keys []string `json:"keys"`
+}
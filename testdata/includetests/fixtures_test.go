@@ -0,0 +1,8 @@
+package includetests
+
+// +schema
+// OrderFixture is only generated with --include-tests.
+type OrderFixture struct {
+	Name  string `json:"name" validate:"required"`
+	Order Order  `json:"order"`
+}
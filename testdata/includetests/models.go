@@ -0,0 +1,9 @@
+// Package includetests contains an annotated struct in a _test.go file,
+// generated only with --include-tests.
+package includetests
+
+// +schema
+// Order is always generated.
+type Order struct {
+	ID string `json:"id" validate:"required"`
+}
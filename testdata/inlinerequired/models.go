@@ -0,0 +1,27 @@
+// Package inlinerequired contains nested inline structs with optional fields.
+package inlinerequired
+
+// +schema:inline
+// Shipment inlines Package, which inlines Dimensions.
+type Shipment struct {
+	ID      string  `json:"id" validate:"required"`
+	Package Package `json:"package" validate:"required"`
+}
+
+// Package has required, pointer and omitempty fields.
+type Package struct {
+	Label string `json:"label" validate:"required"`
+	// Pointers without a required rule are optional
+	Weight *float64 `json:"weight" validate:"omitempty,gt=0"`
+	// json omitempty wins over a required rule
+	Note       string      `json:"note,omitempty" validate:"required"`
+	Dimensions *Dimensions `json:"dimensions"`
+}
+
+// Dimensions is inlined two levels deep.
+type Dimensions struct {
+	Width  int  `json:"width" validate:"required,gt=0"`
+	Height *int `json:"height"`
+	// validate omitempty wins over required
+	Depth int `json:"depth" validate:"required,omitempty,gt=0"`
+}
@@ -0,0 +1,12 @@
+// Package intrinsicbounds contains sized integers generated with --intrinsic-bounds.
+package intrinsicbounds
+
+// +schema
+// Pixel has sized integer fields bounded by their types.
+type Pixel struct {
+	Offset int8   `json:"offset"`
+	Red    uint8  `json:"red"`
+	Port   uint16 `json:"port"`
+	// Validators take precedence over the type's bounds
+	Level int8 `json:"level" validate:"min=1,max=10"`
+}
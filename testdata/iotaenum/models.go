@@ -0,0 +1,39 @@
+package iotaenum
+
+// Color is a palette color.
+type Color int
+
+const (
+	Red Color = iota
+	Green
+	Blue
+)
+
+// Permission is a bit flag.
+type Permission int
+
+const (
+	_ Permission = 1 << iota
+	PermRead
+	PermWrite
+	PermAdmin
+)
+
+// Level starts at one so the zero value is invalid.
+type Level int
+
+const (
+	LevelLow Level = iota + 1
+	LevelMedium
+	LevelHigh
+)
+
+// Theme configures the UI colors.
+// +schema
+type Theme struct {
+	Primary    Color            `json:"primary"`
+	Accents    []Color          `json:"accents"`
+	Permission Permission       `json:"permission"`
+	Contrast   Level            `json:"contrast"`
+	Overrides  map[string]Color `json:"overrides,omitempty"`
+}
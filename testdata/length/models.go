@@ -0,0 +1,10 @@
+package length
+
+// Point is a 3D point.
+// +schema
+type Point struct {
+	Label       string            `json:"label" validate:"len=2"`
+	Coordinates []float64         `json:"coordinates" validate:"required,len=3"`
+	Axes        map[string]string `json:"axes" validate:"len=3"`
+	Tags        []string          `json:"tags" validate:"len=2,dive,len=4"`
+}
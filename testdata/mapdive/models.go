@@ -0,0 +1,15 @@
+// Package mapdive contains map fields validated with dive, keys and endkeys.
+package mapdive
+
+// +schema
+// Inventory uses every form of map dive.
+type Inventory struct {
+	// Bare dive applies to the values
+	Stock map[string]int `json:"stock" validate:"dive,gte=0"`
+	// Key rules between keys and endkeys, value rules after endkeys
+	Labels map[string]string `json:"labels" validate:"min=1,dive,keys,min=2,max=16,endkeys,required,max=64"`
+	// Keys only
+	Regions map[string]bool `json:"regions" validate:"dive,keys,oneof=eu us apac,endkeys"`
+	// Nested dive into map values that are slices
+	Bins map[string][]string `json:"bins" validate:"dive,keys,alpha,endkeys,dive,uuid"`
+}
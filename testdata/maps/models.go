@@ -0,0 +1,33 @@
+package maps
+
+// Region is a deployment region.
+type Region string
+
+const (
+	RegionEU Region = "eu"
+	RegionUS Region = "us"
+)
+
+// Level is a support level.
+type Level int
+
+const (
+	LevelBasic Level = iota + 1
+	LevelPremium
+)
+
+// Endpoint is a service endpoint.
+type Endpoint struct {
+	URL string `json:"url" validate:"required,url"`
+}
+
+// Deployment maps keys to endpoints and quotas.
+// +schema
+type Deployment struct {
+	Endpoints map[string]Endpoint `json:"endpoints"`
+	Regions   map[Region]Endpoint `json:"regions"`
+	Levels    map[Level]string    `json:"levels"`
+	Shards    map[uint16]Endpoint `json:"shards"`
+	Offsets   map[int]int         `json:"offsets" validate:"dive,keys,max=10,endkeys,min=0"`
+	Labels    map[string]string   `json:"labels" validate:"dive,keys,min=1,endkeys"`
+}
@@ -1,7 +1,11 @@
 // Package testdata provides test fixtures for schema generation.
 package testdata
 
-import "time"
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
 
 // Custom type aliases for testing
 type UserID string
@@ -10,6 +14,13 @@ type Counter int
 type Milliseconds int64
 type Percentage float64
 
+// Status values become the enum of Status fields
+const (
+	StatusActive   Status = "active"   // Serving traffic
+	StatusInactive Status = "inactive" // Stopped by an operator
+	StatusPending  Status = "pending"  // Waiting for provisioning
+)
+
 type DetailedCountry struct {
 	ID   string `json:"country_id" validate:"required,len=2,uppercase"`
 	Name string `json:"country_name" validate:"required"`
@@ -32,7 +43,7 @@ type InlineUser struct {
 	// Unique identifier
 	ID string `json:"id" validate:"required,uuid"`
 	// User's email address
-	Email string `json:"email" validate:"required,email"`
+	Email string `json:"email" validate:"required,email" schema:"id=#userEmail"`
 	// Age in years
 	Age int `json:"age" validate:"gte=0,lte=150"`
 	// User's display name
@@ -56,12 +67,18 @@ type User struct {
 	Address Address `json:"address"`
 	// List of roles
 	Roles []string `json:"roles" validate:"dive,oneof=admin user guest"`
+	// Login scores, of which at least two must be perfect
+	Scores []int `json:"scores" schema:"contains={\"const\":100},minContains=2,maxContains=5"`
 	// Account creation time
 	CreatedAt time.Time `json:"created_at"`
 	// Optional metadata
 	Metadata map[string]string `json:"metadata,omitempty"`
 }
 
+// +schema
+// Member is an alias of User sharing its schema
+type Member = User
+
 // Address represents a physical address
 type Address struct {
 	// Street address
@@ -71,7 +88,13 @@ type Address struct {
 	// ZIP or postal code
 	ZipCode string `json:"zip_code" validate:"required,numeric,len=5"`
 	// Country code
-	Country string `json:"country" validate:"required,len=2,uppercase"`
+	Country string `json:"country" validate:"required,len=2,uppercase" schema:"x-ui-widget=select,x-ui-options={\"sort\":true,\"top\":[\"DE\",\"US\"]}"`
+	// Latitude and longitude pair
+	Coordinates [2]any `json:"coordinates,omitempty" schema:"tuple=number,number"`
+	// Apartment or suite, null if none
+	Unit string `json:"unit" schema:"nullable"`
+	// Delivery status, null before the first delivery
+	Delivery Status `json:"delivery" schema:"nullable"`
 }
 
 // Product represents a product in the catalog
@@ -103,6 +126,8 @@ type ServiceConfig struct {
 	RetryDelay time.Duration `json:"retry_delay,omitempty"`
 	// Maximum retry count
 	MaxRetries Counter `json:"max_retries" validate:"gte=0,lte=10"`
+	// Number of replicas, restricted to a few supported sizes
+	Replicas Counter `json:"replicas" validate:"oneof=1 3 5"`
 	// Delay in milliseconds
 	DelayMs Milliseconds `json:"delay_ms"`
 	// Success rate percentage
@@ -113,4 +138,79 @@ type ServiceConfig struct {
 	OperationTimeouts map[string]time.Duration `json:"operation_timeouts,omitempty"`
 	// Custom external type with schema override
 	CustomData interface{} `json:"custom_data,omitempty" schema:"type=object"`
+	// Budget using an externally hosted schema
+	Budget interface{} `json:"budget" validate:"required" schema:"ref=https://example.com/money.schema.json"`
+	// Monthly cost with a custom JSON encoding
+	MonthlyCost Money `json:"monthly_cost"`
+	// Semantic version encoded as text
+	Version SemVer `json:"version" validate:"required"`
+	// Legacy code where validate omitempty wins over required
+	LegacyCode string `json:"legacy_code" validate:"omitempty,required"`
+	// Release channel, overridden without a struct tag
+	// +schema:type=string
+	Channel Address `json:"channel"`
+	Owner   Address `json:"owner"` // +schema:type=object
+}
+
+// Money is an amount in cents that marshals as a decimal string
+type Money struct {
+	Cents int64
+}
+
+// MarshalJSON encodes the amount as a decimal string.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf("\"%d.%02d\"", m.Cents/100, m.Cents%100)), nil
+}
+
+// SemVer is a semantic version that marshals as "major.minor.patch"
+type SemVer struct {
+	Major, Minor, Patch int
+}
+
+// MarshalText encodes the version as text.
+func (v SemVer) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)), nil
+}
+
+// +schema
+// NestedCollections demonstrates deeply nested slice and map types
+type NestedCollections struct {
+	// Slice of maps of integer slices
+	Matrix []map[string][]int `json:"matrix"`
+	// Map of slices of maps
+	Groups map[string][]map[string]string `json:"groups,omitempty"`
+	// Slice of pointer slices
+	Grid [][]*float64 `json:"grid,omitempty"`
+}
+
+// +schema
+// ValidatorCases collects validator mappings that go beyond the basics
+type ValidatorCases struct {
+	// Must not contain "admin"
+	Username string `json:"username" validate:"required,excludes=admin"`
+	// Must not contain any of the listed characters
+	Slug string `json:"slug" validate:"excludesall=!@#-,excludesrune=$"`
+	// Must not start with "tmp." or end with ".bak"
+	Filename string `json:"filename" validate:"startsnotwith=tmp.,endsnotwith=.bak"`
+	// Unsigned integers can never be negative
+	Retries uint `json:"retries"`
+	// Validators override the implied minimum
+	Port uint32 `json:"port" validate:"gte=1,lte=65535"`
+	// Internationalized addresses: the schema tag format wins over the email validator
+	ContactEmail string `json:"contact_email" validate:"omitempty,email" schema:"format=idn-email"`
+	// RFC 5322 addresses map to the email format
+	BillingEmail string `json:"billing_email" validate:"omitempty,email_rfc5322"`
+	// E.164 phone number
+	Phone string `json:"phone" validate:"omitempty,e164"`
+}
+
+// +schema
+// AccountRecord demonstrates database/sql nullable wrapper types
+type AccountRecord struct {
+	// Optional nickname
+	Nickname sql.NullString `json:"nickname" validate:"max=32"`
+	// Optional login count
+	Logins sql.NullInt64 `json:"logins"`
+	// Time of the last login
+	LastLogin sql.NullTime `json:"last_login"`
 }
@@ -0,0 +1,8 @@
+// Package modules is scanned with --recursive; the nested module is skipped.
+package modules
+
+// +schema
+// Host is part of the scanned module.
+type Host struct {
+	Name string `json:"name" validate:"required,hostname"`
+}
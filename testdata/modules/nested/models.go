@@ -0,0 +1,8 @@
+// Package nested is a separate module and skipped unless --cross-module is set.
+package nested
+
+// +schema
+// Plugin must not be generated by a recursive scan of the parent module.
+type Plugin struct {
+	ID string `json:"id"`
+}
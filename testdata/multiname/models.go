@@ -0,0 +1,12 @@
+package multiname
+
+// Point shares one tag between two fields; each field keeps its own name.
+// +schema
+type Point struct {
+	X, Y int     `json:"x" validate:"gte=0"`
+	Z    float64 `json:"z"`
+	// Untagged fields with several names are unaffected
+	Lat, Lng float64
+	// Skipped fields stay skipped
+	A, B string `json:"-"`
+}
@@ -0,0 +1,14 @@
+package negation
+
+// Account is a user account.
+// +schema
+type Account struct {
+	// Username must not be a reserved name
+	Username string `json:"username" validate:"required" schema:"notEnum=root,admin,system"`
+	// Handle must not look like a temporary handle
+	Handle string `json:"handle,omitempty" validate:"excludes=@" schema:"not=pattern:^tmp-"`
+	// Port must not be a privileged default
+	Port int `json:"port,omitempty" schema:"notEnum=22,80,nullable"`
+	// Host must be a name, not an address
+	Host string `json:"host,omitempty" schema:"not=format:ipv4"`
+}
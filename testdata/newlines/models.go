@@ -0,0 +1,20 @@
+// Package newlines contains multi-paragraph doc comments generated with --preserve-newlines.
+package newlines
+
+// +schema
+// Webhook delivers events to an HTTP endpoint.
+//
+// Deliveries are retried with exponential backoff
+// until the endpoint responds with a 2xx status.
+//
+// Supported events:
+//   - order.created
+//   - order.cancelled
+type Webhook struct {
+	// Target URL.
+	//
+	// Must use HTTPS.
+	URL string `json:"url" validate:"required,url"`
+	// Shared secret used to sign payloads
+	Secret string `json:"secret"`
+}
@@ -0,0 +1,15 @@
+package nonempty
+
+// Playlist is a named list of tracks.
+// +schema
+type Playlist struct {
+	Name string `json:"name" validate:"required"`
+	// Tracks must contain at least one entry
+	Tracks []string `json:"tracks" validate:"required"`
+	// Labels are localized names by language code
+	Labels map[string]string `json:"labels" validate:"required"`
+	// Optional collections may be empty
+	Tags []string `json:"tags,omitempty"`
+	// Fixed-size arrays always have their length
+	Position [2]float64 `json:"position" validate:"required"`
+}
@@ -0,0 +1,15 @@
+package nonemptystrings
+
+// Status is an account status.
+type Status string
+
+// Signup is a signup form.
+// +schema
+type Signup struct {
+	Username string  `json:"username" validate:"required"`
+	Password string  `json:"password" validate:"required,min=8"`
+	Nickname string  `json:"nickname"`
+	Status   Status  `json:"status" validate:"required"`
+	Referrer *string `json:"referrer" validate:"required"`
+	Age      int     `json:"age" validate:"required"`
+}
@@ -0,0 +1,15 @@
+// Package norequired contains required fields generated with --no-required.
+package norequired
+
+// +schema:inline
+// Signup has required fields at the root and in an inlined struct.
+type Signup struct {
+	Email    string  `json:"email" validate:"required,email"`
+	Password string  `json:"password" validate:"required,min=8"`
+	Profile  Profile `json:"profile" validate:"required"`
+}
+
+// Profile is inlined into Signup.
+type Profile struct {
+	Name string `json:"name" validate:"required,max=50"`
+}
@@ -0,0 +1,19 @@
+// Package nullable contains pointer fields generated with --nullable-pointers.
+package nullable
+
+import "time"
+
+// +schema
+// Session has optional pointer fields that accept null.
+type Session struct {
+	ID        string     `json:"id" validate:"required"`
+	ExpiresAt *time.Time `json:"expiresAt"`
+	Timeout   *int       `json:"timeout" validate:"omitempty,gte=1"`
+	Owner     *Owner     `json:"owner"`
+}
+
+// +schema
+// Owner of a session.
+type Owner struct {
+	Name string `json:"name"`
+}
@@ -0,0 +1,27 @@
+// Package only contains structs generated with --only Cart,Coupon.
+package only
+
+// +schema
+// Cart is selected and references LineItem, which is written as a dependency.
+type Cart struct {
+	Items []LineItem `json:"items" validate:"required"`
+}
+
+// +schema
+// LineItem is not selected but referenced by Cart.
+type LineItem struct {
+	SKU      string `json:"sku" validate:"required"`
+	Quantity int    `json:"quantity" validate:"gte=1"`
+}
+
+// +schema
+// Coupon is selected.
+type Coupon struct {
+	Code string `json:"code" validate:"required,alphanum"`
+}
+
+// +schema
+// Wishlist is not selected and therefore not written.
+type Wishlist struct {
+	Items []LineItem `json:"items"`
+}
@@ -0,0 +1,18 @@
+// Package openapi30 contains pointer fields generated with --openapi-version 3.0,
+// which takes precedence over --nullable-pointers.
+package openapi30
+
+// +schema
+// Profile has optional pointer fields.
+type Profile struct {
+	Name     string    `json:"name" validate:"required"`
+	Nickname *string   `json:"nickname"`
+	Age      *int      `json:"age" validate:"omitempty,gte=0"`
+	Settings *Settings `json:"settings"`
+}
+
+// +schema
+// Settings of a profile.
+type Settings struct {
+	Theme string `json:"theme"`
+}
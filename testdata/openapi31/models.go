@@ -0,0 +1,17 @@
+// Package openapi31 contains pointer fields generated with --openapi-version 3.1.
+package openapi31
+
+// +schema
+// Profile has optional pointer fields.
+type Profile struct {
+	Name     string    `json:"name" validate:"required"`
+	Nickname *string   `json:"nickname"`
+	Age      *int      `json:"age" validate:"omitempty,gte=0"`
+	Settings *Settings `json:"settings"`
+}
+
+// +schema
+// Settings of a profile.
+type Settings struct {
+	Theme string `json:"theme"`
+}
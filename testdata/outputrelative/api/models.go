@@ -0,0 +1,9 @@
+// Package api is generated next to its source with --output-relative-to file,
+// and into testdata/outputrelative/schemas with --output-relative-to cwd.
+package api
+
+// +schema
+// Request is written to api/schemas.
+type Request struct {
+	Path string `json:"path"`
+}
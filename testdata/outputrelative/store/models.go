@@ -0,0 +1,9 @@
+// Package store is generated next to its source with --output-relative-to file,
+// and into testdata/outputrelative/schemas with --output-relative-to cwd.
+package store
+
+// +schema
+// Record is written to store/schemas.
+type Record struct {
+	Key string `json:"key"`
+}
@@ -0,0 +1,19 @@
+package parameters
+
+// ListOrdersParams are the parameters of the order listing endpoint.
+// +schema
+// +schema:parameters
+type ListOrdersParams struct {
+	// Customer whose orders are listed
+	CustomerID string `form:"customer_id" in:"path" validate:"uuid"`
+
+	// Maximum number of orders returned
+	Limit int `form:"limit" validate:"omitempty,min=1,max=100"`
+
+	// Sort order of the results
+	Sort string `form:"sort" validate:"required,oneof=asc desc"`
+
+	Status []string `form:"status" validate:"dive,oneof=open shipped"`
+
+	RequestID string `form:"X-Request-ID" in:"header"`
+}
@@ -0,0 +1,20 @@
+package parametersembed
+
+// Pagination holds the paging parameters shared by listing endpoints.
+type Pagination struct {
+	// Maximum number of results
+	Limit int `form:"limit" validate:"omitempty,min=1,max=100"`
+
+	// Opaque cursor of the next page
+	Cursor string `form:"cursor"`
+}
+
+// ListUsersParams are the parameters of the user listing endpoint.
+// +schema
+// +schema:parameters
+type ListUsersParams struct {
+	Pagination
+
+	// Team whose users are listed
+	TeamID string `form:"team_id" in:"path"`
+}
@@ -0,0 +1,9 @@
+// Package pkgmode contains structs generated with --package by import path.
+package pkgmode
+
+// +schema
+// Event is loaded through the module graph rather than a directory path.
+type Event struct {
+	Name      string `json:"name" validate:"required"`
+	Timestamp int64  `json:"timestamp" validate:"gte=0"`
+}
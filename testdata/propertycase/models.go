@@ -0,0 +1,15 @@
+// Package propertycase contains untagged fields named with --property-case snake.
+package propertycase
+
+// +schema
+// Profile mixes tagged and untagged fields.
+type Profile struct {
+	// Untagged fields fall back to the converted Go name
+	DisplayName string
+	UserID      string `validate:"required"`
+	HTTPServer  string
+	// An empty tag name also falls back to the converted Go name
+	AvatarURL string `json:",omitempty"`
+	// Tagged names are kept as-is
+	CreatedAt string `json:"createdAt"`
+}
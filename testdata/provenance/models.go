@@ -0,0 +1,13 @@
+package provenance
+
+// Address is a postal address.
+type Address struct {
+	City string `json:"city"`
+}
+
+// Customer is a registered customer.
+// +schema
+type Customer struct {
+	Name    string  `json:"name" validate:"required"`
+	Address Address `json:"address"`
+}
@@ -0,0 +1,13 @@
+package billing
+
+// Config holds billing settings.
+type Config struct {
+	Currency string `json:"currency" validate:"required,len=3"`
+}
+
+// Invoice is billed with the billing config.
+// +schema
+type Invoice struct {
+	Number string `json:"number" validate:"required"`
+	Config Config `json:"config"`
+}
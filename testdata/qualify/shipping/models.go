@@ -0,0 +1,14 @@
+package shipping
+
+// Config holds shipping settings.
+type Config struct {
+	Carrier string `json:"carrier" validate:"required"`
+	Express bool   `json:"express,omitempty"`
+}
+
+// Shipment is sent with the shipping config.
+// +schema
+type Shipment struct {
+	TrackingID string `json:"tracking_id" validate:"required"`
+	Config     Config `json:"config"`
+}
@@ -0,0 +1,9 @@
+// Package querytags contains echo binding structs generated with --tag query.
+package querytags
+
+// +schema
+// ListRequest binds query parameters.
+type ListRequest struct {
+	Cursor string `query:"cursor" json:"next"`
+	Limit  int    `query:"limit,omitempty" validate:"max=50"`
+}
@@ -0,0 +1,15 @@
+package rawmessage
+
+import "encoding/json"
+
+// Event carries a payload decoded later by its type.
+// +schema
+type Event struct {
+	Type string `json:"type" validate:"required"`
+	// Payload is any JSON value
+	Payload json.RawMessage `json:"payload"`
+	// Attributes accept any JSON value per key
+	Attributes map[string]json.RawMessage `json:"attributes"`
+	// Batch of raw JSON values
+	Batch []json.RawMessage `json:"batch,omitempty"`
+}
@@ -0,0 +1,22 @@
+package readonly
+
+import "time"
+
+// Article is a published article.
+// +schema
+type Article struct {
+	ID        string     `json:"id"`
+	Title     string     `json:"title" validate:"required"`
+	Author    Author     `json:"author"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	// Matched by its Go field name
+	Revision int `json:"rev"`
+}
+
+// Author wrote an article.
+// +schema
+type Author struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
@@ -0,0 +1,27 @@
+package refstyle
+
+// Order is placed by a customer.
+// +schema
+type Order struct {
+	ID       string     `json:"id" validate:"required"`
+	Customer Customer   `json:"customer" validate:"required"`
+	Lines    []LineItem `json:"lines" validate:"required"`
+}
+
+// Customer places orders.
+type Customer struct {
+	Name    string   `json:"name" validate:"required"`
+	Billing *Address `json:"billing,omitempty"`
+}
+
+// LineItem is a single order position.
+type LineItem struct {
+	SKU      string `json:"sku" validate:"required"`
+	Quantity int    `json:"quantity" validate:"min=1"`
+}
+
+// Address is a postal address.
+type Address struct {
+	Street string `json:"street"`
+	City   string `json:"city"`
+}
@@ -0,0 +1,19 @@
+package registry
+
+// Author writes posts.
+type Author struct {
+	Name string `json:"name"`
+}
+
+// Post is a blog post.
+// +schema
+type Post struct {
+	Title  string `json:"title"`
+	Author Author `json:"author"`
+}
+
+// Comment is a reply to a post.
+// +schema
+type Comment struct {
+	Body string `json:"body"`
+}
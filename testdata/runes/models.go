@@ -0,0 +1,10 @@
+// Package runes contains rune fields generated without --rune-as-string.
+package runes
+
+// +schema
+// Separator configures how values are joined.
+type Separator struct {
+	Delimiter rune   `json:"delimiter"`
+	Escapes   []rune `json:"escapes"`
+	Quote     *rune  `json:"quote"`
+}
@@ -0,0 +1,10 @@
+// Package runestring contains rune fields generated with --rune-as-string.
+package runestring
+
+// +schema
+// Separator configures how values are joined.
+type Separator struct {
+	Delimiter rune   `json:"delimiter"`
+	Escapes   []rune `json:"escapes"`
+	Quote     *rune  `json:"quote"`
+}
@@ -0,0 +1,8 @@
+// Package scan contains a nested testdata directory scanned only with --include-testdata.
+package scan
+
+// +schema
+// Catalog is always generated.
+type Catalog struct {
+	Name string `json:"name" validate:"required"`
+}
@@ -0,0 +1,8 @@
+// Package testdata contains a fixture generated only with --include-testdata.
+package testdata
+
+// +schema
+// CatalogFixture is only generated with --include-testdata.
+type CatalogFixture struct {
+	Items []string `json:"items"`
+}
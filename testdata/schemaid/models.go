@@ -0,0 +1,23 @@
+// Package schemaid contains structs generated with --schema-id and --ref-style id.
+package schemaid
+
+// +schema
+// Tenant uses the --schema-id template.
+type Tenant struct {
+	Name    string  `json:"name" validate:"required"`
+	Plan    Plan    `json:"plan" validate:"required"`
+	Billing Billing `json:"billing"`
+}
+
+// +schema
+// Billing is referenced through an absolute ref based on --schema-id.
+type Billing struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+// +schema
+// +schema:id=https://example.com/custom/plan.json
+// Plan overrides its $id with a marker.
+type Plan struct {
+	Tier string `json:"tier" validate:"required,oneof=free pro"`
+}
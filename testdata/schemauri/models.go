@@ -0,0 +1,7 @@
+package schemauri
+
+// Tenant is validated against an internally hosted meta-schema.
+// +schema
+type Tenant struct {
+	Name string `json:"name" validate:"required"`
+}
@@ -0,0 +1,14 @@
+// Package skip contains structs generated with --skip LegacyReport.
+package skip
+
+// +schema
+// Report is generated.
+type Report struct {
+	Title string `json:"title" validate:"required"`
+}
+
+// +schema
+// LegacyReport is annotated but excluded with --skip.
+type LegacyReport struct {
+	Name string `json:"name"`
+}
@@ -0,0 +1,9 @@
+package splitenum
+
+// Ticket is a support ticket.
+// +schema
+type Ticket struct {
+	Title    string   `json:"title" validate:"required"`
+	Status   Status   `json:"status" validate:"required"`
+	Priority Priority `json:"priority"`
+}
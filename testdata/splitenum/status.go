@@ -0,0 +1,12 @@
+package splitenum
+
+// Status is the state of a ticket. Its values are declared in values.go.
+type Status string
+
+// Priority orders tickets.
+type Priority int
+
+const (
+	PriorityLow Priority = iota + 1
+	PriorityHigh
+)
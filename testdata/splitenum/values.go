@@ -0,0 +1,7 @@
+package splitenum
+
+const (
+	StatusOpen    Status = "open"
+	StatusPending Status = "pending"
+	StatusClosed  Status = "closed"
+)
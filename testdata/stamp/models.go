@@ -0,0 +1,8 @@
+// Package stamp contains a struct generated with --stamp.
+package stamp
+
+// +schema
+// Release records the generator that produced its schema.
+type Release struct {
+	Tag string `json:"tag" validate:"required"`
+}
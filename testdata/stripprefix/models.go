@@ -0,0 +1,18 @@
+package stripprefix
+
+// LegacyRecord uses the old x_ naming convention.
+// +schema
+type LegacyRecord struct {
+	ID    string `json:"x_id" validate:"required"`
+	Name  string `json:"x_name"`
+	Owner Owner  `json:"x_owner"`
+	// Names without the prefix are unchanged
+	Version int `json:"version"`
+	// A name consisting only of the prefix is kept
+	Raw string `json:"x_"`
+}
+
+// Owner is referenced with a prefixed name.
+type Owner struct {
+	Email string `json:"x_email" validate:"required,email"`
+}
@@ -0,0 +1,18 @@
+// Package tagpriority contains gin binding tags generated with
+// --tag-priority validate,binding.
+package tagpriority
+
+// +schema
+// SignupRequest mixes validate and binding tags.
+type SignupRequest struct {
+	// Only a binding tag
+	Email string `json:"email" binding:"required,email"`
+
+	// Both tags: validate wins on max, binding adds min
+	Username string `json:"username" validate:"max=20" binding:"min=3,max=50"`
+
+	// Both tags: validate wins on oneof
+	Plan string `json:"plan" validate:"oneof=free pro" binding:"required,oneof=free pro enterprise"`
+
+	Tags []string `json:"tags" binding:"dive,min=2"`
+}
@@ -0,0 +1,26 @@
+package titles
+
+// Shipping address. The address a parcel is delivered to; used for
+// customs forms when shipping abroad.
+// +schema
+type Address struct {
+	Street  string `json:"street" validate:"required"`
+	Country string `json:"country" validate:"required,iso3166_1_alpha2"`
+}
+
+// A customer order.
+// +schema
+type Order struct {
+	ID      string  `json:"id" validate:"required"`
+	Address Address `json:"address"`
+}
+
+// +schema
+type Invoice struct {
+	Number string `json:"number"`
+}
+
+// +schema
+type UserIDs struct {
+	IDs []string `json:"ids"`
+}
@@ -0,0 +1,57 @@
+package tsenums
+
+// Status is the lifecycle state of an account.
+type Status string
+
+const (
+	StatusActive    Status = "active"
+	StatusSuspended Status = "suspended"
+	StatusClosed    Status = "closed"
+)
+
+// Priority orders support requests.
+type Priority int
+
+const (
+	PriorityLow Priority = iota + 1
+	PriorityHigh
+)
+
+// Channel is a way to contact an account.
+type Channel string
+
+const (
+	ChannelEmail Channel = "email"
+	ChannelSMS   Channel = "sms"
+)
+
+// Region is not used by any schema, so no TypeScript is written for it.
+type Region string
+
+const (
+	RegionEU Region = "eu"
+	RegionUS Region = "us"
+)
+
+// Level is only used by the skipped Audit type.
+type Level int
+
+const (
+	LevelInfo Level = iota
+	LevelWarn
+)
+
+// Account is a customer account.
+// +schema
+type Account struct {
+	Name     string    `json:"name"`
+	Status   Status    `json:"status"`
+	Priority Priority  `json:"priority"`
+	Channels []Channel `json:"channels"`
+}
+
+// Audit is excluded with --skip.
+// +schema
+type Audit struct {
+	Level Level `json:"level"`
+}
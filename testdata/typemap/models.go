@@ -0,0 +1,19 @@
+// Package typemap contains structs using third-party nullable types,
+// generated with --type-map.
+package typemap
+
+import (
+	"github.com/guregu/null/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// +schema
+// Customer is a record using third-party nullable types.
+type Customer struct {
+	ID       int64         `json:"id" validate:"required"`
+	Nickname null.String   `json:"nickname"`
+	Age      null.Int      `json:"age"`
+	JoinedAt pgtype.Date   `json:"joinedAt"`
+	Balance  pgtype.Int8   `json:"balance"`
+	Tags     []null.String `json:"tags,omitempty"`
+}
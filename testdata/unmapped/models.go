@@ -0,0 +1,19 @@
+package unmapped
+
+import (
+	"net/url"
+	"time"
+)
+
+// Schedule runs a job at fixed times.
+// +schema
+type Schedule struct {
+	// Start is mapped to a date-time string
+	Start time.Time `json:"start"`
+	// Location is an external struct reduced to object
+	Location *time.Location `json:"location,omitempty"`
+	// Callbacks are external structs reduced to object
+	Callbacks []url.URL `json:"callbacks,omitempty"`
+	// Endpoint overrides the external type with a string
+	Endpoint url.URL `json:"endpoint" schema:"type=string"`
+}